@@ -0,0 +1,96 @@
+// authz package defines the permission model shared by the board and task services: the
+// permissions that can be granted on a board and the built-in roles mapped to them. Custom
+// roles defined by board Admins are stored by the board service as sets of these permissions.
+package authz
+
+import (
+	"slices"
+	"strings"
+
+	"encore.dev/beta/errs"
+)
+
+// Board permissions.
+const (
+	BoardView   = "board.view"   // view the board, its members, stages and transitions
+	BoardManage = "board.manage" // archive the board and manage stages, transitions and WIP limits
+	BoardDelete = "board.delete" // move the board to the trash and restore it
+
+	MemberInvite = "member.invite" // invite users and manage join links
+	MemberRemove = "member.remove" // remove other members from the board
+	RoleManage   = "role.manage"   // manage custom roles and change member roles
+
+	TaskView      = "task.view"       // list and read tasks
+	TaskCreate    = "task.create"     // create tasks and clone the board
+	TaskUpdateOwn = "task.update.own" // update tasks created by the user
+	TaskUpdateAny = "task.update.any" // update any task
+	TaskDeleteOwn = "task.delete.own" // delete tasks created by the user
+	TaskDeleteAny = "task.delete.any" // delete any task
+
+	TaskMoveRestricted = "task.move.restricted" // move tasks along transitions marked admin_only
+)
+
+// Built-in roles.
+const (
+	RoleAdmin  = "Admin"
+	RoleMember = "Member"
+	RoleViewer = "Viewer"
+)
+
+// All lists every permission that can be granted on a board.
+var All = []string{
+	BoardView, BoardManage, BoardDelete,
+	MemberInvite, MemberRemove, RoleManage,
+	TaskView, TaskCreate, TaskUpdateOwn, TaskUpdateAny, TaskDeleteOwn, TaskDeleteAny,
+	TaskMoveRestricted,
+}
+
+// builtinRoles maps the built-in roles to their permissions.
+var builtinRoles = map[string][]string{
+	RoleAdmin:  All,
+	RoleMember: {BoardView, TaskView, TaskCreate, TaskUpdateOwn, TaskDeleteOwn},
+	RoleViewer: {BoardView, TaskView},
+}
+
+// IsBuiltin reports whether role is one of the built-in roles.
+func IsBuiltin(role string) bool {
+	_, ok := builtinRoles[role]
+	return ok
+}
+
+// BuiltinPermissions returns the permissions of a built-in role, or nil for other roles.
+func BuiltinPermissions(role string) []string {
+	return slices.Clone(builtinRoles[role])
+}
+
+// Valid reports whether perm is a known permission.
+func Valid(perm string) bool {
+	return slices.Contains(All, perm)
+}
+
+// Has reports whether perms grants perm.
+func Has(perms []string, perm string) bool {
+	return slices.Contains(perms, perm)
+}
+
+// Missing returns the permissions of want that perms does not grant.
+func Missing(perms, want []string) []string {
+	var missing []string
+	for _, perm := range want {
+		if !Has(perms, perm) {
+			missing = append(missing, perm)
+		}
+	}
+	return missing
+}
+
+// Require returns nil if perms grants at least one of anyOf, and a PermissionDenied error
+// otherwise. Both services check permissions through it.
+func Require(perms []string, anyOf ...string) error {
+	for _, perm := range anyOf {
+		if Has(perms, perm) {
+			return nil
+		}
+	}
+	return errs.B().Code(errs.PermissionDenied).Msgf("access denied: requires permission '%s'", strings.Join(anyOf, "' or '")).Err()
+}
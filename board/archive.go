@@ -0,0 +1,58 @@
+package board
+
+import (
+	"context"
+	"time"
+
+	"encore.app/authz"
+	"encore.dev/beta/auth"
+	"encore.dev/beta/errs"
+	"encore.dev/storage/sqldb"
+)
+
+// ArchiveBoard archives a board, making its tasks read-only and hiding it from the default
+// board listing, restricted to users with the board.manage permission.
+//
+//encore:api auth method=POST path=/board/:boardID/archive
+func ArchiveBoard(ctx context.Context, boardID string) (*BoardResponse, error) {
+	return setArchived(ctx, boardID, true)
+}
+
+// UnarchiveBoard makes an archived board editable again, restricted to users with the
+// board.manage permission.
+//
+//encore:api auth method=POST path=/board/:boardID/unarchive
+func UnarchiveBoard(ctx context.Context, boardID string) (*BoardResponse, error) {
+	return setArchived(ctx, boardID, false)
+}
+
+// setArchived archives or unarchives a board on behalf of a user with the board.manage permission.
+func setArchived(ctx context.Context, boardID string, archived bool) (*BoardResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if _, err := authorize(ctx, boardID, uid, authz.BoardManage); err != nil {
+		return nil, err
+	}
+
+	var resp BoardResponse
+	var createdAt time.Time
+	err := boardDB.QueryRow(ctx, `
+        UPDATE boards
+        SET archived_at = CASE WHEN $2 THEN COALESCE(archived_at, NOW()) END
+        WHERE id = $1 AND deleted_at IS NULL
+        RETURNING id, name, description, created_by, created_at, archived_at IS NOT NULL
+    `, boardID, archived).Scan(&resp.ID, &resp.Name, &resp.Description, &resp.CreatedBy, &createdAt, &resp.Archived)
+	if err != nil {
+		if err == sqldb.ErrNoRows {
+			// The board was deleted after the permission check.
+			return nil, errs.B().Code(errs.NotFound).Msg("board not found").Err()
+		}
+		return nil, errs.B().Code(errs.Internal).Msg("failed to update board").Cause(err).Err()
+	}
+	resp.CreatedAt = createdAt.Format(time.RFC3339)
+
+	return &resp, nil
+}
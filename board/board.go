@@ -7,9 +7,11 @@ import (
 	"context"
 	"time"
 
+	"encore.app/authz"
 	"encore.dev/beta/auth"
 	"encore.dev/beta/errs"
 	"encore.dev/pubsub"
+	"encore.dev/rlog"
 	"encore.dev/storage/sqldb"
 )
 
@@ -19,8 +21,8 @@ var boardDB = sqldb.NewDatabase("boards", sqldb.DatabaseConfig{
 	Migrations: "./migrations",
 })
 
-// BoardDeletedEvent represents an event published when a board is deleted, used for
-// cascading deletes in task service.
+// BoardDeletedEvent represents an event published when a board is permanently deleted
+// (purged from the trash), used for cascading deletes in task service.
 type BoardDeletedEvent struct {
 	BoardID string `json:"board_id"`
 }
@@ -31,10 +33,24 @@ var BoardDeletedTopic = pubsub.NewTopic[*BoardDeletedEvent]("board-deleted", pub
 	DeliveryGuarantee: pubsub.AtLeastOnce,
 })
 
+// MemberRemovedEvent represents an event published when a user leaves or is removed from a
+// board, used by task service to unassign the user's tasks.
+type MemberRemovedEvent struct {
+	BoardID string `json:"board_id"`
+	UserID  string `json:"user_id"`
+}
+
+// MemberRemovedTopic is a Pub/Sub topic for notifying task service
+// when a member is removed from a board.
+var MemberRemovedTopic = pubsub.NewTopic[*MemberRemovedEvent]("member-removed", pubsub.TopicConfig{
+	DeliveryGuarantee: pubsub.AtLeastOnce,
+})
+
 // CreateBoardParams defines the input parameters for creating a new board.
 type CreateBoardParams struct {
 	Name        string `json:"name"`                  // Name of the board
 	Description string `json:"description,omitempty"` // Description of the board
+	TemplateID  string `json:"template_id,omitempty"` // Template to instantiate the board from (optional)
 }
 
 // BoardResponse represents the response returned when a board is created or retrieved.
@@ -44,9 +60,11 @@ type BoardResponse struct {
 	Description string `json:"description"` // board description
 	CreatedBy   string `json:"created_by"`  // UID of board Owner
 	CreatedAt   string `json:"created_at"`  // Board creation time
+	Archived    bool   `json:"archived"`    // true if the board is archived (read-only)
 }
 
-// CreateBoard creates a new board and assigns the authenticated user as its Admin.
+// CreateBoard creates a new board and assigns the authenticated user as its Admin. If a
+// template is given, its labels and starter tasks are created on the board by task service.
 //
 //encore:api auth method=POST path=/board
 func CreateBoard(ctx context.Context, p *CreateBoardParams) (*BoardResponse, error) {
@@ -59,30 +77,65 @@ func CreateBoard(ctx context.Context, p *CreateBoardParams) (*BoardResponse, err
 		return nil, errs.B().Code(errs.InvalidArgument).Msg("name is required").Err()
 	}
 
-	var boardID string
-	err := boardDB.QueryRow(ctx, `
-        INSERT INTO boards (name, description, created_by)
-        VALUES ($1, $2, $3)
-        RETURNING id
-    `, p.Name, p.Description, uid).Scan(&boardID)
+	description := p.Description
+	var stages []string
+	var tmpl *TemplateResponse
+	if p.TemplateID != "" {
+		var err error
+		tmpl, err = getTemplate(ctx, p.TemplateID, string(uid))
+		if err != nil {
+			if err == sqldb.ErrNoRows {
+				return nil, errs.B().Code(errs.NotFound).Msg("template not found").Err()
+			}
+			return nil, errs.B().Code(errs.Internal).Msg("failed to fetch template").Cause(err).Err()
+		}
+		if description == "" {
+			description = tmpl.Description
+		}
+		stages = tmpl.Stages
+	}
+
+	tx, err := boardDB.Begin(ctx)
 	if err != nil {
-		return nil, errs.B().Code(errs.Internal).Msg("failed to create board").Cause(err).Err()
+		return nil, errs.B().Code(errs.Internal).Msg("failed to start transaction").Cause(err).Err()
 	}
+	defer tx.Rollback()
 
-	_, err = boardDB.Exec(ctx, `
-        INSERT INTO board_members (board_id, user_id, role)
-        VALUES ($1, $2, 'Admin')
-    `, boardID, uid)
+	boardID, createdAt, err := insertBoard(ctx, tx, p.Name, description, string(uid), stages)
 	if err != nil {
-		return nil, errs.B().Code(errs.Internal).Msg("failed to assign admin role").Cause(err).Err()
+		return nil, errs.B().Code(errs.Internal).Msg("failed to create board").Cause(err).Err()
+	}
+
+	var eventID int64
+	if tmpl != nil && (len(tmpl.Tasks) > 0 || len(tmpl.Labels) > 0) {
+		eventID, err = enqueueEvent(ctx, tx, "board-seed", &BoardSeedEvent{
+			BoardID:      boardID,
+			CreatedBy:    string(uid),
+			StarterTasks: tmpl.Tasks,
+			Labels:       tmpl.Labels,
+		})
+		if err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to record board seed event").Cause(err).Err()
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to commit transaction").Cause(err).Err()
+	}
+
+	if eventID != 0 {
+		// Publish right away; if this fails the outbox relay retries the event later.
+		if _, _, err := relayOutbox(ctx, eventID); err != nil {
+			rlog.Warn("failed to publish board seed event, leaving it to the outbox relay", "board_id", boardID, "err", err)
+		}
 	}
 
 	return &BoardResponse{
 		ID:          boardID,
 		Name:        p.Name,
-		Description: p.Description,
+		Description: description,
 		CreatedBy:   string(uid),
-		CreatedAt:   time.Now().Format(time.RFC3339),
+		CreatedAt:   createdAt.Format(time.RFC3339),
 	}, nil
 }
 
@@ -90,7 +143,7 @@ func CreateBoard(ctx context.Context, p *CreateBoardParams) (*BoardResponse, err
 type InviteUserParams struct {
 	BoardID   string `json:"board_id"`   // target board id
 	InviteeID string `json:"invitee_id"` // id of user who is going to be invited
-	Role      string `json:"role"`       // Must be "Member" or "Viewer"
+	Role      string `json:"role"`       // "Member", "Viewer", or a custom role of the board
 }
 
 // InviteResponse represents the response when an invitation is created.
@@ -98,7 +151,8 @@ type InviteResponse struct {
 	InvitationID string `json:"invitation_id"` // invitation id
 }
 
-// InviteUser invites a user to a board, restricted to Admins only.
+// InviteUser invites a user to a board, restricted to users with the member.invite permission.
+// The invitation can only grant a role whose permissions the inviter holds.
 //
 //encore:api auth method=POST path=/board/invite
 func InviteUser(ctx context.Context, p *InviteUserParams) (*InviteResponse, error) {
@@ -111,17 +165,16 @@ func InviteUser(ctx context.Context, p *InviteUserParams) (*InviteResponse, erro
 		return nil, errs.B().Code(errs.InvalidArgument).Msg("board_id, invitee_id, and role are required").Err()
 	}
 
-	var role string
-	err := boardDB.QueryRow(ctx, `
-        SELECT role FROM board_members
-        WHERE board_id = $1 AND user_id = $2
-    `, p.BoardID, uid).Scan(&role)
-	if err != nil || role != "Admin" {
-		return nil, errs.B().Code(errs.PermissionDenied).Msg("only Admin can invite users").Err()
+	granted, err := authorize(ctx, p.BoardID, uid, authz.MemberInvite)
+	if err != nil {
+		return nil, err
 	}
 
-	if p.Role != "Member" && p.Role != "Viewer" {
-		return nil, errs.B().Code(errs.InvalidArgument).Msg("role must be 'Member' or 'Viewer'").Err()
+	if err := validateAssignableRole(ctx, p.BoardID, p.Role); err != nil {
+		return nil, err
+	}
+	if err := checkGrantableRole(ctx, p.BoardID, p.Role, granted); err != nil {
+		return nil, err
 	}
 
 	var invitationID string
@@ -165,11 +218,20 @@ func HandleInvitation(ctx context.Context, p *HandleInvitationParams) (*HandleIn
 		return nil, errs.B().Code(errs.InvalidArgument).Msg("action must be 'Accepted' or 'Rejected'").Err()
 	}
 
+	tx, err := boardDB.Begin(ctx)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to start transaction").Cause(err).Err()
+	}
+	defer tx.Rollback()
+
+	// Lock the invitation row so concurrent requests cannot process it twice.
 	var boardID, status, role string
-	err := boardDB.QueryRow(ctx, `
-        SELECT board_id, status, role
-        FROM invitations
-        WHERE id = $1 AND invitee_id = $2
+	err = tx.QueryRow(ctx, `
+        SELECT i.board_id, i.status, i.role
+        FROM invitations i
+        JOIN boards b ON i.board_id = b.id
+        WHERE i.id = $1 AND i.invitee_id = $2 AND b.deleted_at IS NULL
+        FOR UPDATE OF i
     `, p.InvitationID, uid).Scan(&boardID, &status, &role)
 	if err != nil {
 		if err == sqldb.ErrNoRows {
@@ -182,7 +244,14 @@ func HandleInvitation(ctx context.Context, p *HandleInvitationParams) (*HandleIn
 	}
 
 	if p.Action == "Accepted" {
-		_, err = boardDB.Exec(ctx, `
+		// The custom role of the invitation may have been deleted since it was sent.
+		if err := validateAssignableRole(ctx, boardID, role); err != nil {
+			if errs.Code(err) == errs.Internal {
+				return nil, err
+			}
+			return nil, errs.B().Code(errs.FailedPrecondition).Msg("the role of this invitation no longer exists").Err()
+		}
+		_, err = tx.Exec(ctx, `
             INSERT INTO board_members (board_id, user_id, role)
             VALUES ($1, $2, $3)
             ON CONFLICT DO NOTHING
@@ -192,7 +261,7 @@ func HandleInvitation(ctx context.Context, p *HandleInvitationParams) (*HandleIn
 		}
 	}
 
-	_, err = boardDB.Exec(ctx, `
+	_, err = tx.Exec(ctx, `
         UPDATE invitations
         SET status = $1
         WHERE id = $2
@@ -201,6 +270,10 @@ func HandleInvitation(ctx context.Context, p *HandleInvitationParams) (*HandleIn
 		return nil, errs.B().Code(errs.Internal).Msg("failed to update invitation status").Cause(err).Err()
 	}
 
+	if err := tx.Commit(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to commit transaction").Cause(err).Err()
+	}
+
 	return &HandleInvitationResponse{BoardID: boardID}, nil
 }
 
@@ -213,26 +286,16 @@ func GetBoard(ctx context.Context, boardID string) (*BoardResponse, error) {
 		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
 	}
 
-	var exists bool
-	err := boardDB.QueryRow(ctx, `
-        SELECT EXISTS (
-            SELECT 1 FROM board_members
-            WHERE board_id = $1 AND user_id = $2
-        )
-    `, boardID, uid).Scan(&exists)
-	if err != nil {
-		return nil, errs.B().Code(errs.Internal).Msg("failed to check membership").Cause(err).Err()
-	}
-	if !exists {
-		return nil, errs.B().Code(errs.PermissionDenied).Msg("access denied: not a member of this board").Err()
+	if _, err := authorize(ctx, boardID, uid, authz.BoardView); err != nil {
+		return nil, err
 	}
 
 	var resp BoardResponse
-	err = boardDB.QueryRow(ctx, `
-        SELECT id, name, description, created_by, created_at
+	err := boardDB.QueryRow(ctx, `
+        SELECT id, name, description, created_by, created_at, archived_at IS NOT NULL
         FROM boards
-        WHERE id = $1
-    `, boardID).Scan(&resp.ID, &resp.Name, &resp.Description, &resp.CreatedBy, &resp.CreatedAt)
+        WHERE id = $1 AND deleted_at IS NULL
+    `, boardID).Scan(&resp.ID, &resp.Name, &resp.Description, &resp.CreatedBy, &resp.CreatedAt, &resp.Archived)
 	if err != nil {
 		if err == sqldb.ErrNoRows {
 			return nil, errs.B().Code(errs.NotFound).Msg("board not found").Err()
@@ -243,6 +306,57 @@ func GetBoard(ctx context.Context, boardID string) (*BoardResponse, error) {
 	return &resp, nil
 }
 
+// ListBoardsParams defines the query parameters for listing boards.
+type ListBoardsParams struct {
+	IncludeArchived bool `query:"include_archived"` // include archived boards (default false)
+}
+
+// ListBoardsResponse represents the boards the authenticated user is a member of.
+type ListBoardsResponse struct {
+	Boards []BoardResponse `json:"boards"`
+}
+
+// ListBoards retrieves the boards the authenticated user is a member of. Archived boards are
+// only included when requested.
+//
+//encore:api auth method=GET path=/boards
+func ListBoards(ctx context.Context, p *ListBoardsParams) (*ListBoardsResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	rows, err := boardDB.Query(ctx, `
+        SELECT b.id, b.name, b.description, b.created_by, b.created_at, b.archived_at IS NOT NULL
+        FROM boards b
+        JOIN board_members m ON m.board_id = b.id
+        WHERE m.user_id = $1 AND b.deleted_at IS NULL
+          AND ($2 OR b.archived_at IS NULL)
+        ORDER BY b.created_at DESC
+    `, uid, p.IncludeArchived)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch boards").Cause(err).Err()
+	}
+	defer rows.Close()
+
+	var boards []BoardResponse
+	for rows.Next() {
+		var b BoardResponse
+		var createdAt time.Time
+		if err := rows.Scan(&b.ID, &b.Name, &b.Description, &b.CreatedBy, &createdAt, &b.Archived); err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to scan board").Cause(err).Err()
+		}
+		b.CreatedAt = createdAt.Format(time.RFC3339)
+		boards = append(boards, b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("error reading boards").Cause(err).Err()
+	}
+
+	return &ListBoardsResponse{Boards: boards}, nil
+}
+
 // InvitationResponse represents a single invitation with board details.
 type InvitationResponse struct {
 	InvitationID string `json:"invitation_id"` // invitation id
@@ -274,7 +388,7 @@ func ListInvitations(ctx context.Context, status string) (*ListInvitationsRespon
         SELECT i.id, i.board_id, b.name, i.inviter_id, i.created_at
         FROM invitations i
         JOIN boards b ON i.board_id = b.id
-        WHERE i.invitee_id = $1 AND i.status = $2
+        WHERE i.invitee_id = $1 AND i.status = $2 AND b.deleted_at IS NULL
         ORDER BY i.created_at DESC
     `, uid, status)
 	if err != nil {
@@ -303,7 +417,8 @@ type RemoveUserResponse struct {
 	Message string `json:"message"`
 }
 
-// RemoveUser removes a user from a board, allowed by Admins or the user themselves.
+// RemoveUser removes a user from a board, allowed for users with the member.remove permission
+// or the user themselves.
 //
 //encore:api auth method=DELETE path=/board/:boardID/user/:userID
 func RemoveUser(ctx context.Context, boardID, userID string) (*RemoveUserResponse, error) {
@@ -312,55 +427,62 @@ func RemoveUser(ctx context.Context, boardID, userID string) (*RemoveUserRespons
 		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
 	}
 
-	var role string
-	err := boardDB.QueryRow(ctx, `
-        SELECT role FROM board_members
-        WHERE board_id = $1 AND user_id = $2
-    `, boardID, uid).Scan(&role)
+	tx, err := boardDB.Begin(ctx)
 	if err != nil {
-		return nil, errs.B().Code(errs.PermissionDenied).Msg("access denied: not a member or insufficient permissions").Err()
-	}
-	if role != "Admin" && string(uid) != userID {
-		return nil, errs.B().Code(errs.PermissionDenied).Msg("only Admin or the user themselves can remove a user").Err()
+		return nil, errs.B().Code(errs.Internal).Msg("failed to start transaction").Cause(err).Err()
 	}
+	defer tx.Rollback()
 
-	var exists bool
-	err = boardDB.QueryRow(ctx, `
-        SELECT EXISTS (
-            SELECT 1 FROM board_members
-            WHERE board_id = $1 AND user_id = $2
-        )
-    `, boardID, userID).Scan(&exists)
+	// Lock the board's member rows so the admin count cannot change until we commit.
+	rows, err := tx.Query(ctx, `
+        SELECT m.user_id, m.role
+        FROM board_members m
+        JOIN boards b ON m.board_id = b.id
+        WHERE m.board_id = $1 AND b.deleted_at IS NULL
+        FOR UPDATE OF m
+    `, boardID)
 	if err != nil {
-		return nil, errs.B().Code(errs.Internal).Msg("failed to check membership").Cause(err).Err()
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch members").Cause(err).Err()
 	}
-	if !exists {
-		return nil, errs.B().Code(errs.NotFound).Msg("user not a member of this board").Err()
+	roles := make(map[string]string)
+	adminCount := 0
+	for rows.Next() {
+		var memberID, r string
+		if err := rows.Scan(&memberID, &r); err != nil {
+			rows.Close()
+			return nil, errs.B().Code(errs.Internal).Msg("failed to scan member").Cause(err).Err()
+		}
+		roles[memberID] = r
+		if r == authz.RoleAdmin {
+			adminCount++
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("error reading members").Cause(err).Err()
 	}
 
-	var adminCount int
-	err = boardDB.QueryRow(ctx, `
-        SELECT COUNT(*) FROM board_members
-        WHERE board_id = $1 AND role = 'Admin'
-    `, boardID).Scan(&adminCount)
-	if err != nil {
-		return nil, errs.B().Code(errs.Internal).Msg("failed to count admins").Cause(err).Err()
+	role, isMember := roles[string(uid)]
+	if !isMember {
+		return nil, errs.B().Code(errs.PermissionDenied).Msg("access denied: not a member or insufficient permissions").Err()
+	}
+	if string(uid) != userID {
+		// The role comes from the rows locked above, so it cannot change before we commit.
+		if _, err := authorizeRole(ctx, boardID, role, authz.MemberRemove); err != nil {
+			return nil, err
+		}
 	}
 
-	var targetRole string
-	err = boardDB.QueryRow(ctx, `
-        SELECT role FROM board_members
-        WHERE board_id = $1 AND user_id = $2
-    `, boardID, userID).Scan(&targetRole)
-	if err != nil {
-		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch target role").Cause(err).Err()
+	targetRole, exists := roles[userID]
+	if !exists {
+		return nil, errs.B().Code(errs.NotFound).Msg("user not a member of this board").Err()
 	}
 
-	if targetRole == "Admin" && adminCount <= 1 {
+	if targetRole == authz.RoleAdmin && adminCount <= 1 {
 		return nil, errs.B().Code(errs.FailedPrecondition).Msg("cannot remove the last Admin").Err()
 	}
 
-	_, err = boardDB.Exec(ctx, `
+	_, err = tx.Exec(ctx, `
         DELETE FROM board_members
         WHERE board_id = $1 AND user_id = $2
     `, boardID, userID)
@@ -368,6 +490,20 @@ func RemoveUser(ctx context.Context, boardID, userID string) (*RemoveUserRespons
 		return nil, errs.B().Code(errs.Internal).Msg("failed to remove user").Cause(err).Err()
 	}
 
+	eventID, err := enqueueEvent(ctx, tx, "member-removed", &MemberRemovedEvent{BoardID: boardID, UserID: userID})
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to record member removed event").Cause(err).Err()
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to commit transaction").Cause(err).Err()
+	}
+
+	// Publish right away; if this fails the relay job retries.
+	if _, _, err := relayOutbox(ctx, eventID); err != nil {
+		rlog.Warn("failed to publish member removed event, leaving it to the outbox relay", "board_id", boardID, "err", err)
+	}
+
 	return &RemoveUserResponse{Message: "User removed successfully"}, nil
 }
 
@@ -376,7 +512,9 @@ type RemoveBoardResponse struct {
 	Message string `json:"message"`
 }
 
-// RemoveBoard deletes a board and publishes a deletion event, restricted to Admins.
+// RemoveBoard moves a board to the trash, restricted to users with the board.delete permission.
+// The board can be restored until the trash retention period ends, after which it is purged
+// and its tasks deleted.
 //
 //encore:api auth method=DELETE path=/board/:boardID
 func RemoveBoard(ctx context.Context, boardID string) (*RemoveBoardResponse, error) {
@@ -385,19 +523,15 @@ func RemoveBoard(ctx context.Context, boardID string) (*RemoveBoardResponse, err
 		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
 	}
 
-	var role string
-	err := boardDB.QueryRow(ctx, `
-        SELECT role FROM board_members
-        WHERE board_id = $1 AND user_id = $2
-    `, boardID, uid).Scan(&role)
-	if err != nil || role != "Admin" {
-		return nil, errs.B().Code(errs.PermissionDenied).Msg("only Admin can delete a board").Err()
+	if _, err := authorize(ctx, boardID, uid, authz.BoardDelete); err != nil {
+		return nil, err
 	}
 
 	result, err := boardDB.Exec(ctx, `
-        DELETE FROM boards
-        WHERE id = $1
-    `, boardID)
+        UPDATE boards
+        SET deleted_at = NOW(), deleted_by = $2
+        WHERE id = $1 AND deleted_at IS NULL
+    `, boardID, uid)
 	if err != nil {
 		return nil, errs.B().Code(errs.Internal).Msg("failed to delete board").Cause(err).Err()
 	}
@@ -408,12 +542,7 @@ func RemoveBoard(ctx context.Context, boardID string) (*RemoveBoardResponse, err
 		return nil, errs.B().Code(errs.NotFound).Msg("board not found").Err()
 	}
 
-	_, err = BoardDeletedTopic.Publish(ctx, &BoardDeletedEvent{BoardID: boardID})
-	if err != nil {
-		return nil, errs.B().Code(errs.Internal).Msg("failed to publish board deletion event").Cause(err).Err()
-	}
-
-	return &RemoveBoardResponse{Message: "Board deleted successfully"}, nil
+	return &RemoveBoardResponse{Message: "Board moved to trash"}, nil
 }
 
 // MemberResponse represents a single board member.
@@ -436,18 +565,8 @@ func ListBoardMembers(ctx context.Context, boardID string) (*ListBoardMembersRes
 		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
 	}
 
-	var exists bool
-	err := boardDB.QueryRow(ctx, `
-        SELECT EXISTS (
-            SELECT 1 FROM board_members
-            WHERE board_id = $1 AND user_id = $2
-        )
-    `, boardID, uid).Scan(&exists)
-	if err != nil {
-		return nil, errs.B().Code(errs.Internal).Msg("failed to check membership").Cause(err).Err()
-	}
-	if !exists {
-		return nil, errs.B().Code(errs.PermissionDenied).Msg("access denied: not a member of this board").Err()
+	if _, err := authorize(ctx, boardID, uid, authz.BoardView); err != nil {
+		return nil, err
 	}
 
 	rows, err := boardDB.Query(ctx, `
@@ -477,13 +596,34 @@ func ListBoardMembers(ctx context.Context, boardID string) (*ListBoardMembersRes
 	return &ListBoardMembersResponse{Members: members}, nil
 }
 
+// memberRole returns the role of a user on a board. It returns sqldb.ErrNoRows if the user
+// is not a member or the board is in the trash.
+func memberRole(ctx context.Context, boardID, userID string) (string, error) {
+	var role string
+	err := boardDB.QueryRow(ctx, `
+        SELECT m.role
+        FROM board_members m
+        JOIN boards b ON m.board_id = b.id
+        WHERE m.board_id = $1 AND m.user_id = $2 AND b.deleted_at IS NULL
+    `, boardID, userID).Scan(&role)
+	return role, err
+}
+
 // CheckMembershipResponse indicates whether a user is a member of a board and their role.
 type CheckMembershipResponse struct {
-	IsMember bool   `json:"is_member"`      // true if member
-	Role     string `json:"role,omitempty"` // Empty if not a member
+	IsMember        bool     `json:"is_member"`                  // true if member
+	BoardName       string   `json:"board_name"`                 // name of the board, empty if not a member
+	Role            string   `json:"role,omitempty"`             // Empty if not a member
+	Permissions     []string `json:"permissions"`                // permissions granted by the role
+	Archived        bool     `json:"archived"`                   // true if the board is archived (read-only)
+	Stages          []string `json:"stages"`                     // stages of the board in column order
+	StageIDs        []string `json:"stage_ids"`                  // ids of the stages, in the same order
+	StagesVersion   int      `json:"stages_version"`             // stage layout version, see GetStageLayout
+	DefaultPriority string   `json:"default_priority,omitempty"` // priority of new tasks that do not specify one
 }
 
-// CheckMembership checks if the authenticated user is a member of a board and returns their role.
+// CheckMembership checks if the authenticated user is a member of a board and returns their role
+// and permissions, whether the board is archived, its stages and its default task priority.
 //
 //encore:api auth method=GET path=/board/:boardID/membership
 func CheckMembership(ctx context.Context, boardID string) (*CheckMembershipResponse, error) {
@@ -492,11 +632,15 @@ func CheckMembership(ctx context.Context, boardID string) (*CheckMembershipRespo
 		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
 	}
 
-	var role string
+	var role, boardName, defaultPriority string
+	var archived bool
+	var stagesVersion int
 	err := boardDB.QueryRow(ctx, `
-        SELECT role FROM board_members
-        WHERE board_id = $1 AND user_id = $2
-    `, boardID, uid).Scan(&role)
+        SELECT m.role, b.name, b.archived_at IS NOT NULL, b.default_priority, b.stages_version
+        FROM board_members m
+        JOIN boards b ON m.board_id = b.id
+        WHERE m.board_id = $1 AND m.user_id = $2 AND b.deleted_at IS NULL
+    `, boardID, uid).Scan(&role, &boardName, &archived, &defaultPriority, &stagesVersion)
 	if err != nil {
 		if err == sqldb.ErrNoRows {
 			return &CheckMembershipResponse{IsMember: false}, nil
@@ -504,5 +648,164 @@ func CheckMembership(ctx context.Context, boardID string) (*CheckMembershipRespo
 		return nil, errs.B().Code(errs.Internal).Msg("failed to check membership").Cause(err).Err()
 	}
 
-	return &CheckMembershipResponse{IsMember: true, Role: role}, nil
+	perms, err := rolePermissions(ctx, boardID, role)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch role permissions").Cause(err).Err()
+	}
+
+	stages, err := listStages(ctx, boardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch stages").Cause(err).Err()
+	}
+	names := make([]string, len(stages))
+	ids := make([]string, len(stages))
+	for i, s := range stages {
+		names[i], ids[i] = s.Name, s.ID
+	}
+
+	return &CheckMembershipResponse{
+		IsMember:        true,
+		BoardName:       boardName,
+		Role:            role,
+		Permissions:     perms,
+		Archived:        archived,
+		Stages:          names,
+		StageIDs:        ids,
+		StagesVersion:   stagesVersion,
+		DefaultPriority: defaultPriority,
+	}, nil
+}
+
+// MemberBoardsParams defines the permission required on the listed boards.
+type MemberBoardsParams struct {
+	Permission string `query:"permission"` // only list boards where the user's role grants this permission
+}
+
+// MemberBoard represents a board the authenticated user is a member of.
+type MemberBoard struct {
+	BoardID       string   `json:"board_id"`       // board id
+	BoardName     string   `json:"board_name"`     // name of the board
+	Role          string   `json:"role"`           // role of the user on the board
+	Archived      bool     `json:"archived"`       // true if the board is archived (read-only)
+	Stages        []string `json:"stages"`         // stages of the board in column order
+	StageIDs      []string `json:"stage_ids"`      // ids of the stages, in the same order
+	StagesVersion int      `json:"stages_version"` // stage layout version, see GetStageLayout
+}
+
+// MemberBoardsResponse lists the boards the authenticated user is a member of, ordered by name.
+type MemberBoardsResponse struct {
+	Boards []MemberBoard `json:"boards"`
+}
+
+// ListMemberBoards retrieves the active boards the authenticated user is a member of and whose
+// role grants the requested permission. It is used by task service to query tasks across boards.
+//
+//encore:api private method=GET path=/internal/me/boards
+func ListMemberBoards(ctx context.Context, p *MemberBoardsParams) (*MemberBoardsResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if !authz.Valid(p.Permission) {
+		return nil, errs.B().Code(errs.InvalidArgument).Msgf("unknown permission '%s'", p.Permission).Err()
+	}
+
+	rows, err := boardDB.Query(ctx, `
+        SELECT b.id, b.name, m.role, b.archived_at IS NOT NULL,
+               ARRAY(SELECT s.name FROM board_stages s WHERE s.board_id = b.id ORDER BY s.position),
+               ARRAY(SELECT s.id::text FROM board_stages s WHERE s.board_id = b.id ORDER BY s.position),
+               b.stages_version
+        FROM boards b
+        JOIN board_members m ON m.board_id = b.id
+        WHERE m.user_id = $1 AND b.deleted_at IS NULL
+        ORDER BY b.name, b.id
+    `, uid)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch boards").Cause(err).Err()
+	}
+	defer rows.Close()
+
+	var all []MemberBoard
+	for rows.Next() {
+		var b MemberBoard
+		if err := rows.Scan(&b.BoardID, &b.BoardName, &b.Role, &b.Archived, &b.Stages, &b.StageIDs, &b.StagesVersion); err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to scan board").Cause(err).Err()
+		}
+		all = append(all, b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("error reading boards").Cause(err).Err()
+	}
+
+	boards := []MemberBoard{}
+	for _, b := range all {
+		perms, err := rolePermissions(ctx, b.BoardID, b.Role)
+		if err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to fetch role permissions").Cause(err).Err()
+		}
+		if authz.Has(perms, p.Permission) {
+			boards = append(boards, b)
+		}
+	}
+
+	return &MemberBoardsResponse{Boards: boards}, nil
+}
+
+// CheckMembersParams defines the users whose membership of a board is checked.
+type CheckMembersParams struct {
+	UserIDs []string `json:"user_ids"`
+}
+
+// MemberPermissions represents a board member with the permissions granted by their role.
+type MemberPermissions struct {
+	UserID      string   `json:"user_id"`
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+}
+
+// CheckMembersResponse lists which of the requested users are members of a board. Users that
+// are not members, or do not exist, are omitted.
+type CheckMembersResponse struct {
+	Members []MemberPermissions `json:"members"`
+}
+
+// CheckMembers checks which of a set of users are members of an active board and returns their
+// roles and permissions. It is used by task service to validate assignees.
+//
+//encore:api private method=POST path=/internal/board/:boardID/members/check
+func CheckMembers(ctx context.Context, boardID string, p *CheckMembersParams) (*CheckMembersResponse, error) {
+	rows, err := boardDB.Query(ctx, `
+        SELECT m.user_id, m.role
+        FROM board_members m
+        JOIN boards b ON m.board_id = b.id
+        WHERE m.board_id = $1 AND m.user_id::text = ANY($2::text[]) AND b.deleted_at IS NULL
+    `, boardID, p.UserIDs)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch members").Cause(err).Err()
+	}
+	defer rows.Close()
+
+	members := []MemberPermissions{}
+	for rows.Next() {
+		var m MemberPermissions
+		if err := rows.Scan(&m.UserID, &m.Role); err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to scan member").Cause(err).Err()
+		}
+		members = append(members, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("error reading members").Cause(err).Err()
+	}
+
+	for i := range members {
+		members[i].Permissions, err = rolePermissions(ctx, boardID, members[i].Role)
+		if err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to fetch role permissions").Cause(err).Err()
+		}
+	}
+
+	return &CheckMembersResponse{Members: members}, nil
 }
@@ -0,0 +1,155 @@
+//go:build encore_app
+
+package board
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+
+	"encore.app/authz"
+	"encore.dev/beta/auth"
+	"encore.dev/beta/errs"
+)
+
+// concurrently runs fn n times at once and returns the errors of the calls.
+func concurrently(n int, fn func(i int) error) []error {
+	results := make([]error, n)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			results[i] = fn(i)
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+	return results
+}
+
+// countSuccesses returns how many errors are nil, failing the test on errors with codes other
+// than the expected ones.
+func countSuccesses(t *testing.T, results []error, expected ...errs.ErrCode) int {
+	t.Helper()
+	n := 0
+	for _, err := range results {
+		switch {
+		case err == nil:
+			n++
+		case !slices.Contains(expected, errs.Code(err)):
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	return n
+}
+
+// memberRows returns how many membership rows a user has on a board.
+func memberRows(t *testing.T, boardID string, uid auth.UID) int {
+	t.Helper()
+	var n int
+	err := boardDB.QueryRow(context.Background(), `
+        SELECT COUNT(*) FROM board_members
+        WHERE board_id = $1 AND user_id = $2
+    `, boardID, uid).Scan(&n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+// adminCount returns how many Admins a board has.
+func adminCount(t *testing.T, boardID string) int {
+	t.Helper()
+	var n int
+	err := boardDB.QueryRow(context.Background(), `
+        SELECT COUNT(*) FROM board_members
+        WHERE board_id = $1 AND role = $2
+    `, boardID, authz.RoleAdmin).Scan(&n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestHandleInvitationConcurrentAccept(t *testing.T) {
+	boardID, admin := newBoard(t)
+	invitee := newUserID(t)
+	inv, err := InviteUser(as(admin), &InviteUserParams{BoardID: boardID, InviteeID: string(invitee), Role: authz.RoleMember})
+	if err != nil {
+		t.Fatalf("InviteUser: %v", err)
+	}
+
+	results := concurrently(10, func(int) error {
+		_, err := HandleInvitation(as(invitee), &HandleInvitationParams{InvitationID: inv.InvitationID, Action: "Accepted"})
+		return err
+	})
+
+	if n := countSuccesses(t, results, errs.FailedPrecondition); n != 1 {
+		t.Errorf("invitation accepted %d times, want 1", n)
+	}
+	if n := memberRows(t, boardID, invitee); n != 1 {
+		t.Errorf("invitee has %d membership rows, want 1", n)
+	}
+}
+
+func TestHandleInvitationConcurrentAcceptAndReject(t *testing.T) {
+	boardID, admin := newBoard(t)
+	invitee := newUserID(t)
+	inv, err := InviteUser(as(admin), &InviteUserParams{BoardID: boardID, InviteeID: string(invitee), Role: authz.RoleViewer})
+	if err != nil {
+		t.Fatalf("InviteUser: %v", err)
+	}
+
+	actions := []string{"Accepted", "Rejected"}
+	results := concurrently(10, func(i int) error {
+		_, err := HandleInvitation(as(invitee), &HandleInvitationParams{InvitationID: inv.InvitationID, Action: actions[i%2]})
+		return err
+	})
+
+	if n := countSuccesses(t, results, errs.FailedPrecondition); n != 1 {
+		t.Fatalf("invitation processed %d times, want 1", n)
+	}
+	var status string
+	err = boardDB.QueryRow(context.Background(), `
+        SELECT status FROM invitations WHERE id = $1
+    `, inv.InvitationID).Scan(&status)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := 0
+	if status == "Accepted" {
+		want = 1
+	}
+	if n := memberRows(t, boardID, invitee); n != want {
+		t.Errorf("invitation %s but invitee has %d membership rows", status, n)
+	}
+}
+
+func TestRemoveUserConcurrentLastAdmin(t *testing.T) {
+	boardID, admin := newBoard(t)
+	if _, err := CreateRole(as(admin), boardID, &RoleParams{Name: "Moderator", Permissions: []string{authz.BoardView, authz.MemberRemove}}); err != nil {
+		t.Fatalf("CreateRole: %v", err)
+	}
+	moderator := addMember(t, boardID, admin, "Moderator")
+
+	// The Admin tries to leave while the Moderator tries to remove them.
+	results := concurrently(10, func(i int) error {
+		caller := admin
+		if i%2 == 1 {
+			caller = moderator
+		}
+		_, err := RemoveUser(as(caller), boardID, string(admin))
+		return err
+	})
+
+	if n := countSuccesses(t, results, errs.FailedPrecondition); n != 0 {
+		t.Errorf("last Admin removed %d times", n)
+	}
+	if n := adminCount(t, boardID); n != 1 {
+		t.Errorf("board has %d Admins, want 1", n)
+	}
+}
@@ -0,0 +1,236 @@
+package board
+
+import (
+	"context"
+	"time"
+
+	"encore.app/authz"
+	"encore.dev/beta/auth"
+	"encore.dev/beta/errs"
+	"encore.dev/pubsub"
+	"encore.dev/rlog"
+	"encore.dev/storage/sqldb"
+)
+
+// BoardSeedEvent represents an event published when a new board is cloned or created from
+// a template, used by task service to populate the board with tasks and labels.
+type BoardSeedEvent struct {
+	BoardID       string          `json:"board_id"`                  // board to populate
+	CreatedBy     string          `json:"created_by"`                // UID recorded as creator of the new tasks
+	SourceBoardID string          `json:"source_board_id,omitempty"` // copy tasks from this board, if set
+	SourceStages  []string        `json:"source_stages,omitempty"`   // only copy tasks in these stages (all if empty)
+	KeepAssignees bool            `json:"keep_assignees,omitempty"`  // keep assignees of copied tasks
+	StarterTasks  []StarterTask   `json:"starter_tasks,omitempty"`   // tasks to create from a template
+	Labels        []TemplateLabel `json:"labels,omitempty"`          // labels to create from a template
+}
+
+// BoardSeedTopic is a Pub/Sub topic for notifying task service
+// when a new board needs to be populated with tasks.
+var BoardSeedTopic = pubsub.NewTopic[*BoardSeedEvent]("board-seed", pubsub.TopicConfig{
+	DeliveryGuarantee: pubsub.AtLeastOnce,
+})
+
+// CloneBoardParams defines the input parameters for cloning a board.
+type CloneBoardParams struct {
+	Name         string   `json:"name,omitempty"`          // name of the new board, defaults to "<source name> (copy)"
+	CopyMembers  bool     `json:"copy_members,omitempty"`  // copy board members (requires member.invite)
+	CopyTasks    bool     `json:"copy_tasks,omitempty"`    // copy tasks of the source board, asynchronously after the clone returns
+	TaskStages   []string `json:"task_stages,omitempty"`   // only copy tasks in these stages (all if empty)
+	CopySettings bool     `json:"copy_settings,omitempty"` // copy board settings (description, stages, transitions, roles and default priority)
+}
+
+// CloneBoard creates a new board from an existing one, restricted to users with the task.create
+// permission; copying members additionally requires member.invite.
+// The authenticated user becomes Admin of the new board.
+//
+// Tasks are copied asynchronously: the copy is requested with a BoardSeedEvent committed
+// together with the new board, and task service creates the tasks when it receives the event.
+// Board service cannot call task service directly, as task service depends on it. CloneBoard
+// therefore returns before the tasks exist, so the new board may briefly show no or only some
+// tasks, and failures to copy them are not reported to the caller. Task service retries the
+// event if copying fails, and copies the tasks only once however often the event is delivered.
+//
+//encore:api auth method=POST path=/board/:boardID/clone
+func CloneBoard(ctx context.Context, boardID string, p *CloneBoardParams) (*BoardResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if _, err := authorize(ctx, boardID, uid, authz.TaskCreate); err != nil {
+		return nil, err
+	}
+	if p.CopyMembers {
+		if _, err := authorize(ctx, boardID, uid, authz.MemberInvite); err != nil {
+			return nil, err
+		}
+	}
+
+	var sourceName, sourceDesc string
+	err := boardDB.QueryRow(ctx, `
+        SELECT name, description FROM boards
+        WHERE id = $1
+    `, boardID).Scan(&sourceName, &sourceDesc)
+	if err != nil {
+		if err == sqldb.ErrNoRows {
+			return nil, errs.B().Code(errs.NotFound).Msg("board not found").Err()
+		}
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch board").Cause(err).Err()
+	}
+
+	name := p.Name
+	if name == "" {
+		name = sourceName + " (copy)"
+	}
+	description := ""
+	if p.CopySettings {
+		description = sourceDesc
+	}
+
+	// Copied tasks keep their stage, so the stages are copied along with them.
+	var stages []string
+	if p.CopySettings || p.CopyTasks {
+		stages, err = stageNames(ctx, boardID)
+		if err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to fetch stages").Cause(err).Err()
+		}
+	}
+
+	tx, err := boardDB.Begin(ctx)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to start transaction").Cause(err).Err()
+	}
+	defer tx.Rollback()
+
+	newBoardID, createdAt, err := insertBoard(ctx, tx, name, description, string(uid), stages)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to create board").Cause(err).Err()
+	}
+
+	if p.CopySettings {
+		if err := copyBoardSettings(ctx, tx, boardID, newBoardID); err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to copy board settings").Cause(err).Err()
+		}
+	}
+
+	// Copied members may hold custom roles, so the roles are copied along with them.
+	if p.CopySettings || p.CopyMembers {
+		if err := copyBoardRoles(ctx, tx, boardID, newBoardID); err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to copy board roles").Cause(err).Err()
+		}
+	}
+
+	if p.CopyMembers {
+		// The new board has a single Admin (the caller); other Admins join as Members.
+		_, err = tx.Exec(ctx, `
+            INSERT INTO board_members (board_id, user_id, role)
+            SELECT $1, user_id, CASE WHEN role = 'Admin' THEN 'Member' ELSE role END
+            FROM board_members
+            WHERE board_id = $2 AND user_id <> $3
+        `, newBoardID, boardID, uid)
+		if err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to copy board members").Cause(err).Err()
+		}
+	}
+
+	var eventID int64
+	if p.CopyTasks {
+		eventID, err = enqueueEvent(ctx, tx, "board-seed", &BoardSeedEvent{
+			BoardID:       newBoardID,
+			CreatedBy:     string(uid),
+			SourceBoardID: boardID,
+			SourceStages:  p.TaskStages,
+			KeepAssignees: p.CopyMembers,
+		})
+		if err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to record board seed event").Cause(err).Err()
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to commit transaction").Cause(err).Err()
+	}
+
+	if eventID != 0 {
+		// Publish right away; if this fails the outbox relay retries the event later.
+		if _, _, err := relayOutbox(ctx, eventID); err != nil {
+			rlog.Warn("failed to publish board seed event, leaving it to the outbox relay", "board_id", newBoardID, "err", err)
+		}
+	}
+
+	return &BoardResponse{
+		ID:          newBoardID,
+		Name:        name,
+		Description: description,
+		CreatedBy:   string(uid),
+		CreatedAt:   createdAt.Format(time.RFC3339),
+	}, nil
+}
+
+// insertBoard creates a board row with the given stages and assigns adminID as its Admin
+// within tx. The default stages are used if stages is empty.
+func insertBoard(ctx context.Context, tx *sqldb.Tx, name, description, adminID string, stages []string) (string, time.Time, error) {
+	var boardID string
+	var createdAt time.Time
+	err := tx.QueryRow(ctx, `
+        INSERT INTO boards (name, description, created_by)
+        VALUES ($1, $2, $3)
+        RETURNING id, created_at
+    `, name, description, adminID).Scan(&boardID, &createdAt)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO board_members (board_id, user_id, role)
+        VALUES ($1, $2, 'Admin')
+    `, boardID, adminID)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	if len(stages) == 0 {
+		stages = defaultStages
+	}
+	if err := insertStages(ctx, tx, boardID, stages); err != nil {
+		return "", time.Time{}, err
+	}
+
+	return boardID, createdAt, nil
+}
+
+// copyBoardSettings copies per-stage configuration (WIP limits and transitions) from one board
+// to another within tx. Both boards must already have stages with the same names.
+func copyBoardSettings(ctx context.Context, tx *sqldb.Tx, fromBoardID, toBoardID string) error {
+	_, err := tx.Exec(ctx, `
+        UPDATE boards n
+        SET default_priority = o.default_priority
+        FROM boards o
+        WHERE n.id = $1 AND o.id = $2
+    `, toBoardID, fromBoardID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+        UPDATE board_stages n
+        SET wip_limit = o.wip_limit, wip_mode = o.wip_mode
+        FROM board_stages o
+        WHERE n.board_id = $1 AND o.board_id = $2 AND o.name = n.name
+    `, toBoardID, fromBoardID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO board_stage_transitions (board_id, from_stage_id, to_stage_id, admin_only, required_fields)
+        SELECT $1, nf.id, nt.id, r.admin_only, r.required_fields
+        FROM board_stage_transitions r
+        JOIN board_stages f ON f.id = r.from_stage_id
+        JOIN board_stages t ON t.id = r.to_stage_id
+        JOIN board_stages nf ON nf.board_id = $1 AND nf.name = f.name
+        JOIN board_stages nt ON nt.board_id = $1 AND nt.name = t.name
+        WHERE r.board_id = $2
+    `, toBoardID, fromBoardID)
+	return err
+}
@@ -0,0 +1,2 @@
+// Number of days a deleted board stays in the trash before it is purged.
+TrashRetentionDays: 30
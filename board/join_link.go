@@ -0,0 +1,277 @@
+package board
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"encore.app/authz"
+	"encore.dev/beta/auth"
+	"encore.dev/beta/errs"
+	"encore.dev/storage/sqldb"
+)
+
+// CreateJoinLinkParams defines the input parameters for creating a shareable join link.
+type CreateJoinLinkParams struct {
+	Role      string `json:"role"`                 // "Member", "Viewer", or a custom role of the board
+	MaxUses   int    `json:"max_uses,omitempty"`   // maximum number of joins, 0 for unlimited
+	ExpiresAt string `json:"expires_at,omitempty"` // RFC3339 expiry time, empty for no expiry
+}
+
+// JoinLinkResponse represents a single join link of a board.
+type JoinLinkResponse struct {
+	ID        string `json:"id"`                   // join link id
+	BoardID   string `json:"board_id"`             // board id
+	Token     string `json:"token"`                // secret token used in POST /board/join/:token
+	Role      string `json:"role"`                 // role granted to users joining via the link
+	MaxUses   int    `json:"max_uses"`             // maximum number of joins, 0 for unlimited
+	UseCount  int    `json:"use_count"`            // number of users that joined via the link
+	ExpiresAt string `json:"expires_at,omitempty"` // expiry time, empty if the link never expires
+	CreatedBy string `json:"created_by"`           // UID of the member who created the link
+	CreatedAt string `json:"created_at"`           // time of creating the link
+}
+
+// CreateJoinLink generates a shareable join link for a board, restricted to users with the
+// member.invite permission. The link can only grant a role whose permissions the user holds.
+//
+//encore:api auth method=POST path=/board/:boardID/join-links
+func CreateJoinLink(ctx context.Context, boardID string, p *CreateJoinLinkParams) (*JoinLinkResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	granted, err := authorize(ctx, boardID, uid, authz.MemberInvite)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateAssignableRole(ctx, boardID, p.Role); err != nil {
+		return nil, err
+	}
+	if err := checkGrantableRole(ctx, boardID, p.Role, granted); err != nil {
+		return nil, err
+	}
+	if p.MaxUses < 0 {
+		return nil, errs.B().Code(errs.InvalidArgument).Msg("max_uses must be non-negative").Err()
+	}
+
+	var expiresAt *time.Time
+	if p.ExpiresAt != "" {
+		t, err := time.Parse(time.RFC3339, p.ExpiresAt)
+		if err != nil {
+			return nil, errs.B().Code(errs.InvalidArgument).Msg("expires_at must be an RFC3339 timestamp").Err()
+		}
+		if !t.After(time.Now()) {
+			return nil, errs.B().Code(errs.InvalidArgument).Msg("expires_at must be in the future").Err()
+		}
+		t = t.UTC()
+		expiresAt = &t
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to generate join token").Cause(err).Err()
+	}
+
+	var id string
+	var createdAt time.Time
+	err = boardDB.QueryRow(ctx, `
+        INSERT INTO board_join_links (board_id, token, role, max_uses, expires_at, created_by)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING id, created_at
+    `, boardID, token, p.Role, p.MaxUses, expiresAt, uid).Scan(&id, &createdAt)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to create join link").Cause(err).Err()
+	}
+
+	resp := &JoinLinkResponse{
+		ID:        id,
+		BoardID:   boardID,
+		Token:     token,
+		Role:      p.Role,
+		MaxUses:   p.MaxUses,
+		CreatedBy: string(uid),
+		CreatedAt: createdAt.Format(time.RFC3339),
+	}
+	if expiresAt != nil {
+		resp.ExpiresAt = expiresAt.Format(time.RFC3339)
+	}
+	return resp, nil
+}
+
+// ListJoinLinksResponse represents the active join links of a board.
+type ListJoinLinksResponse struct {
+	JoinLinks []JoinLinkResponse `json:"join_links"`
+}
+
+// ListJoinLinks retrieves all active (not revoked, expired or used up) join links of a board,
+// restricted to users with the member.invite permission.
+//
+//encore:api auth method=GET path=/board/:boardID/join-links
+func ListJoinLinks(ctx context.Context, boardID string) (*ListJoinLinksResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if _, err := authorize(ctx, boardID, uid, authz.MemberInvite); err != nil {
+		return nil, err
+	}
+
+	rows, err := boardDB.Query(ctx, `
+        SELECT id, board_id, token, role, max_uses, use_count, expires_at, created_by, created_at
+        FROM board_join_links
+        WHERE board_id = $1
+          AND revoked_at IS NULL
+          AND (expires_at IS NULL OR expires_at > NOW())
+          AND (max_uses = 0 OR use_count < max_uses)
+        ORDER BY created_at DESC
+    `, boardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch join links").Cause(err).Err()
+	}
+	defer rows.Close()
+
+	var links []JoinLinkResponse
+	for rows.Next() {
+		var l JoinLinkResponse
+		var expiresAt *time.Time
+		var createdAt time.Time
+		if err := rows.Scan(&l.ID, &l.BoardID, &l.Token, &l.Role, &l.MaxUses, &l.UseCount, &expiresAt, &l.CreatedBy, &createdAt); err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to scan join link").Cause(err).Err()
+		}
+		if expiresAt != nil {
+			l.ExpiresAt = expiresAt.Format(time.RFC3339)
+		}
+		l.CreatedAt = createdAt.Format(time.RFC3339)
+		links = append(links, l)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("error reading join links").Cause(err).Err()
+	}
+
+	return &ListJoinLinksResponse{JoinLinks: links}, nil
+}
+
+// RevokeJoinLinkResponse represents the response when a join link is revoked.
+type RevokeJoinLinkResponse struct {
+	Message string `json:"message"`
+}
+
+// RevokeJoinLink revokes a join link so it can no longer be used, restricted to users with the
+// member.invite permission.
+//
+//encore:api auth method=DELETE path=/board/:boardID/join-links/:linkID
+func RevokeJoinLink(ctx context.Context, boardID, linkID string) (*RevokeJoinLinkResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if _, err := authorize(ctx, boardID, uid, authz.MemberInvite); err != nil {
+		return nil, err
+	}
+
+	result, err := boardDB.Exec(ctx, `
+        UPDATE board_join_links
+        SET revoked_at = NOW()
+        WHERE id = $1 AND board_id = $2 AND revoked_at IS NULL
+    `, linkID, boardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to revoke join link").Cause(err).Err()
+	}
+
+	if result.RowsAffected() == 0 {
+		return nil, errs.B().Code(errs.NotFound).Msg("join link not found").Err()
+	}
+
+	return &RevokeJoinLinkResponse{Message: "Join link revoked successfully"}, nil
+}
+
+// JoinBoardResponse represents the response when a user joins a board via a join link.
+type JoinBoardResponse struct {
+	BoardID string `json:"board_id"` // board id
+	Role    string `json:"role"`     // role granted on the board
+}
+
+// JoinBoard adds the authenticated user to a board using a shareable join link.
+//
+//encore:api auth method=POST path=/board/join/:token
+func JoinBoard(ctx context.Context, token string) (*JoinBoardResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	tx, err := boardDB.Begin(ctx)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to start transaction").Cause(err).Err()
+	}
+	defer tx.Rollback()
+
+	// Lock the link row so concurrent joins cannot exceed max_uses.
+	var linkID, boardID, role string
+	err = tx.QueryRow(ctx, `
+        SELECT l.id, l.board_id, l.role
+        FROM board_join_links l
+        JOIN boards b ON l.board_id = b.id
+        WHERE l.token = $1
+          AND l.revoked_at IS NULL
+          AND (l.expires_at IS NULL OR l.expires_at > NOW())
+          AND (l.max_uses = 0 OR l.use_count < l.max_uses)
+          AND b.deleted_at IS NULL
+        FOR UPDATE OF l
+    `, token).Scan(&linkID, &boardID, &role)
+	if err != nil {
+		if err == sqldb.ErrNoRows {
+			return nil, errs.B().Code(errs.NotFound).Msg("join link is invalid, expired, or has been used up").Err()
+		}
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch join link").Cause(err).Err()
+	}
+	// The custom role granted by the link may have been deleted since the link was created.
+	if err := validateAssignableRole(ctx, boardID, role); err != nil {
+		if errs.Code(err) == errs.Internal {
+			return nil, err
+		}
+		return nil, errs.B().Code(errs.FailedPrecondition).Msg("the role granted by this join link no longer exists").Err()
+	}
+
+	result, err := tx.Exec(ctx, `
+        INSERT INTO board_members (board_id, user_id, role)
+        VALUES ($1, $2, $3)
+        ON CONFLICT DO NOTHING
+    `, boardID, uid, role)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to add user to board").Cause(err).Err()
+	}
+	if result.RowsAffected() == 0 {
+		return nil, errs.B().Code(errs.AlreadyExists).Msg("already a member of this board").Err()
+	}
+
+	_, err = tx.Exec(ctx, `
+        UPDATE board_join_links
+        SET use_count = use_count + 1
+        WHERE id = $1
+    `, linkID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to update join link usage").Cause(err).Err()
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to commit transaction").Cause(err).Err()
+	}
+
+	return &JoinBoardResponse{BoardID: boardID, Role: role}, nil
+}
+
+// generateToken returns a random URL-safe token for a join or share link.
+func generateToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,15 @@
+-- Board Stages Table
+-- Ordered workflow columns of each board.
+CREATE TABLE board_stages (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    board_id UUID NOT NULL REFERENCES boards(id) ON DELETE CASCADE,  -- Board ID
+    name VARCHAR(50) NOT NULL,
+    position INT NOT NULL,  -- 0-based column order
+    UNIQUE (board_id, name)
+);
+
+-- Existing boards keep the stages that used to be hard-coded.
+INSERT INTO board_stages (board_id, name, position)
+SELECT b.id, s.name, s.position
+FROM boards b
+CROSS JOIN (VALUES ('To Do', 0), ('In Progress', 1), ('Done', 2)) AS s (name, position);
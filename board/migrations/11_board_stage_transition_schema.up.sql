@@ -0,0 +1,12 @@
+-- Board Stage Transitions Table
+-- Allowed moves between stages. A board without rows allows every move.
+CREATE TABLE board_stage_transitions (
+    board_id UUID NOT NULL REFERENCES boards(id) ON DELETE CASCADE,  -- Board ID
+    from_stage_id UUID NOT NULL REFERENCES board_stages(id) ON DELETE CASCADE,
+    to_stage_id UUID NOT NULL REFERENCES board_stages(id) ON DELETE CASCADE,
+    admin_only BOOLEAN NOT NULL DEFAULT FALSE,  -- only Admins may perform the move
+    required_fields TEXT[] NOT NULL DEFAULT '{}',  -- task fields that must be set, e.g. 'assignee_id'
+    PRIMARY KEY (from_stage_id, to_stage_id)
+);
+
+CREATE INDEX board_stage_transitions_board_id_idx ON board_stage_transitions (board_id);
@@ -0,0 +1,4 @@
+-- Work-in-progress limits per stage
+ALTER TABLE board_stages
+    ADD COLUMN wip_limit INT CHECK (wip_limit > 0),  -- NULL means no limit
+    ADD COLUMN wip_mode VARCHAR(10) NOT NULL DEFAULT 'hard' CHECK (wip_mode IN ('hard', 'soft'));  -- 'hard' blocks, 'soft' warns
@@ -0,0 +1,17 @@
+-- Custom roles are stored per board, so member roles are no longer limited to the built-in ones.
+ALTER TABLE board_members DROP CONSTRAINT IF EXISTS board_members_role_check;
+ALTER TABLE board_members ALTER COLUMN role TYPE VARCHAR(50);
+ALTER TABLE invitations DROP CONSTRAINT IF EXISTS invitations_role_check;
+ALTER TABLE invitations ALTER COLUMN role TYPE VARCHAR(50);
+ALTER TABLE board_join_links DROP CONSTRAINT IF EXISTS board_join_links_role_check;
+ALTER TABLE board_join_links ALTER COLUMN role TYPE VARCHAR(50);
+
+-- Board Roles Table
+CREATE TABLE board_roles (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    board_id UUID NOT NULL REFERENCES boards(id) ON DELETE CASCADE,  -- Board ID
+    name VARCHAR(50) NOT NULL,
+    permissions TEXT[] NOT NULL DEFAULT '{}',  -- permission names, e.g. 'task.create'
+    created_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE (board_id, name)
+);
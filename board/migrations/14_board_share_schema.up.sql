@@ -0,0 +1,9 @@
+-- Board Shares Table
+CREATE TABLE board_shares (
+    board_id UUID PRIMARY KEY REFERENCES boards(id) ON DELETE CASCADE,  -- Board ID, a board has at most one public share
+    token TEXT UNIQUE NOT NULL,  -- random token embedded in the public URL
+    password_hash TEXT,  -- bcrypt hash, NULL if no password is required
+    expires_at TIMESTAMP,  -- NULL means the share never expires
+    created_by UUID NOT NULL,  -- user who published the board (User ID from User Service)
+    created_at TIMESTAMP DEFAULT NOW()
+);
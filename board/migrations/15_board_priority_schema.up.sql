@@ -0,0 +1,4 @@
+-- Priority assigned to new tasks that do not specify one
+ALTER TABLE boards
+    ADD COLUMN default_priority VARCHAR(10) NOT NULL DEFAULT 'none'
+        CHECK (default_priority IN ('urgent', 'high', 'medium', 'low', 'none'));
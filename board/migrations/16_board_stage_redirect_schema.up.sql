@@ -0,0 +1,16 @@
+-- Version of a board's stage layout, incremented whenever a stage is renamed or deleted, so task
+-- service can tell when the stages recorded on its tasks are outdated.
+ALTER TABLE boards ADD COLUMN stages_version INT NOT NULL DEFAULT 1;
+
+-- Board Stage Redirects Table
+-- Deleted stages and the stage that received their tasks. Redirects always point to an existing
+-- stage: deleting a stage that receives redirects forwards them to its own target.
+CREATE TABLE board_stage_redirects (
+    stage_id UUID PRIMARY KEY,  -- id of the deleted stage
+    board_id UUID NOT NULL REFERENCES boards(id) ON DELETE CASCADE,  -- Board ID
+    moved_to UUID NOT NULL REFERENCES board_stages(id) ON DELETE CASCADE,  -- stage that received the tasks
+    deleted_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX board_stage_redirects_board_id_idx ON board_stage_redirects (board_id);
+CREATE INDEX board_stage_redirects_moved_to_idx ON board_stage_redirects (moved_to);
@@ -0,0 +1,10 @@
+-- Board Share Attempts Table
+-- Recent password attempts on password-protected shares, used to throttle password guessing.
+-- Rows older than the throttling window are removed as new attempts are recorded.
+CREATE TABLE board_share_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    board_id UUID NOT NULL REFERENCES board_shares(board_id) ON DELETE CASCADE,  -- share the password was tried on
+    attempted_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX board_share_attempts_board_id_idx ON board_share_attempts (board_id, attempted_at);
@@ -0,0 +1,6 @@
+-- Share expiry is compared against NOW(), so it is stored with its time zone to be independent
+-- of the session's time zone. Expiry times were written in UTC.
+ALTER TABLE board_shares ALTER COLUMN expires_at TYPE TIMESTAMPTZ USING expires_at AT TIME ZONE 'UTC';
+
+-- Attempts were recorded with NOW() in the session's time zone, which the implicit cast assumes.
+ALTER TABLE board_share_attempts ALTER COLUMN attempted_at TYPE TIMESTAMPTZ;
@@ -0,0 +1,15 @@
+-- Board Join Links Table
+CREATE TABLE board_join_links (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    board_id UUID REFERENCES boards(id) ON DELETE CASCADE,  -- Board ID
+    token TEXT UNIQUE NOT NULL,  -- random token embedded in the shareable URL
+    role VARCHAR(20) CHECK (role IN ('Member', 'Viewer')) DEFAULT 'Viewer',
+    max_uses INT NOT NULL DEFAULT 0 CHECK (max_uses >= 0),  -- 0 means unlimited
+    use_count INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP,  -- NULL means the link never expires
+    created_by UUID NOT NULL,  -- Admin who created the link (User ID from User Service)
+    revoked_at TIMESTAMP,  -- set when an Admin revokes the link
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX board_join_links_board_id_idx ON board_join_links (board_id);
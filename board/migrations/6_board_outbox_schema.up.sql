@@ -0,0 +1,17 @@
+-- Outbox Table
+-- Events are written here in the same transaction as the change that produced them
+-- and published to Pub/Sub by the outbox relay.
+CREATE TABLE outbox (
+    id BIGSERIAL PRIMARY KEY,
+    topic TEXT NOT NULL,  -- Pub/Sub topic name, e.g. 'board-deleted'
+    payload JSONB NOT NULL,  -- JSON encoded event
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    published_at TIMESTAMP,  -- NULL while the event is pending
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX outbox_pending_idx
+ON outbox (next_attempt_at)
+WHERE published_at IS NULL;
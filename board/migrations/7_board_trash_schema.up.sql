@@ -0,0 +1,8 @@
+-- Soft-delete columns for boards moved to the trash
+ALTER TABLE boards
+    ADD COLUMN deleted_at TIMESTAMP,  -- NULL while the board is active
+    ADD COLUMN deleted_by UUID;  -- Admin who moved the board to the trash (User ID from User Service)
+
+CREATE INDEX boards_deleted_at_idx
+ON boards (deleted_at)
+WHERE deleted_at IS NOT NULL;
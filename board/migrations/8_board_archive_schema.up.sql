@@ -0,0 +1,3 @@
+-- Archive column for read-only boards
+ALTER TABLE boards
+    ADD COLUMN archived_at TIMESTAMP;  -- NULL while the board is not archived
@@ -0,0 +1,13 @@
+-- Board Templates Table
+CREATE TABLE board_templates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name TEXT NOT NULL,
+    description TEXT,  -- description given to boards created from the template
+    created_by UUID NOT NULL,  -- Owner of the template (User ID from User Service)
+    stages JSONB NOT NULL DEFAULT '[]',  -- ordered stage names
+    labels JSONB NOT NULL DEFAULT '[]',  -- [{"name": ..., "color": ...}]
+    tasks JSONB NOT NULL DEFAULT '[]',  -- starter tasks [{"title": ..., "description": ..., "stage": ...}]
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX board_templates_created_by_idx ON board_templates (created_by);
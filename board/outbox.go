@@ -0,0 +1,230 @@
+package board
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"encore.dev/beta/errs"
+	"encore.dev/cron"
+	"encore.dev/rlog"
+	"encore.dev/storage/sqldb"
+)
+
+const (
+	// outboxBatchSize is the maximum number of events published per relay run.
+	outboxBatchSize = 100
+	// outboxMaxBackoff caps the delay between publish attempts of a failing event.
+	outboxMaxBackoff = time.Hour
+)
+
+// The relay-outbox cron job periodically publishes pending outbox events, retrying events whose
+// earlier publish attempts failed.
+var _ = cron.NewJob("relay-outbox", cron.JobConfig{
+	Title:    "Publish pending outbox events",
+	Every:    1 * cron.Minute,
+	Endpoint: RelayOutbox,
+})
+
+// enqueueEvent writes an event for the given topic to the outbox as part of tx, so it is
+// only published if the surrounding change commits. It returns the outbox event id.
+func enqueueEvent(ctx context.Context, tx *sqldb.Tx, topic string, event any) (int64, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+	err = tx.QueryRow(ctx, `
+        INSERT INTO outbox (topic, payload)
+        VALUES ($1, $2)
+        RETURNING id
+    `, topic, payload).Scan(&id)
+	return id, err
+}
+
+// publishOutboxEvent decodes an outbox payload and publishes it to its topic.
+func publishOutboxEvent(ctx context.Context, topic string, payload []byte) error {
+	switch topic {
+	case "board-deleted":
+		var event BoardDeletedEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return err
+		}
+		_, err := BoardDeletedTopic.Publish(ctx, &event)
+		return err
+	case "board-seed":
+		var event BoardSeedEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return err
+		}
+		_, err := BoardSeedTopic.Publish(ctx, &event)
+		return err
+	case "stage-changed":
+		var event StageChangedEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return err
+		}
+		_, err := StageChangedTopic.Publish(ctx, &event)
+		return err
+	case "member-removed":
+		var event MemberRemovedEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return err
+		}
+		_, err := MemberRemovedTopic.Publish(ctx, &event)
+		return err
+	default:
+		return fmt.Errorf("unknown outbox topic %q", topic)
+	}
+}
+
+// RelayOutboxResponse summarizes a single outbox relay run.
+type RelayOutboxResponse struct {
+	Published int `json:"published"` // number of events published
+	Failed    int `json:"failed"`    // number of events that failed and will be retried
+}
+
+// RelayOutbox publishes pending outbox events. It is invoked by the relay cron job.
+//
+//encore:api private method=POST path=/internal/outbox/relay
+func RelayOutbox(ctx context.Context) (*RelayOutboxResponse, error) {
+	published, failed, err := relayOutbox(ctx, 0)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to relay outbox events").Cause(err).Err()
+	}
+	return &RelayOutboxResponse{Published: published, Failed: failed}, nil
+}
+
+// relayOutbox publishes pending outbox events that are due, or only the event with the
+// given id if onlyID is non-zero. Rows are locked with SKIP LOCKED so concurrent relays
+// never publish the same event at the same time.
+func relayOutbox(ctx context.Context, onlyID int64) (published, failed int, err error) {
+	tx, err := boardDB.Begin(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(ctx, `
+        SELECT id, topic, payload, attempts
+        FROM outbox
+        WHERE published_at IS NULL
+          AND next_attempt_at <= NOW()
+          AND ($1::bigint = 0 OR id = $1::bigint)
+        ORDER BY id
+        LIMIT $2
+        FOR UPDATE SKIP LOCKED
+    `, onlyID, outboxBatchSize)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	type outboxEvent struct {
+		id       int64
+		topic    string
+		payload  []byte
+		attempts int
+	}
+	var events []outboxEvent
+	for rows.Next() {
+		var e outboxEvent
+		if err := rows.Scan(&e.id, &e.topic, &e.payload, &e.attempts); err != nil {
+			rows.Close()
+			return 0, 0, err
+		}
+		events = append(events, e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, 0, err
+	}
+
+	for _, e := range events {
+		if pubErr := publishOutboxEvent(ctx, e.topic, e.payload); pubErr != nil {
+			backoff := time.Duration(1<<min(e.attempts, 12)) * time.Second
+			if backoff > outboxMaxBackoff {
+				backoff = outboxMaxBackoff
+			}
+			rlog.Warn("failed to publish outbox event", "id", e.id, "topic", e.topic, "attempts", e.attempts+1, "err", pubErr)
+			_, err = tx.Exec(ctx, `
+                UPDATE outbox
+                SET attempts = attempts + 1, last_error = $1,
+                    next_attempt_at = NOW() + make_interval(secs => $2)
+                WHERE id = $3
+            `, pubErr.Error(), backoff.Seconds(), e.id)
+			if err != nil {
+				return published, failed, err
+			}
+			failed++
+			continue
+		}
+
+		_, err = tx.Exec(ctx, `
+            UPDATE outbox
+            SET attempts = attempts + 1, last_error = NULL, published_at = NOW()
+            WHERE id = $1
+        `, e.id)
+		if err != nil {
+			return published, failed, err
+		}
+		published++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, err
+	}
+	return published, failed, nil
+}
+
+// FindMissingBoardsParams defines the board ids to look up.
+type FindMissingBoardsParams struct {
+	BoardIDs []string `json:"board_ids"` // board ids to check
+}
+
+// FindMissingBoardsResponse lists the board ids that do not exist.
+type FindMissingBoardsResponse struct {
+	Missing []string `json:"missing"` // board ids with no matching board
+}
+
+// FindMissingBoards reports which of the given board ids no longer exist. It is used by
+// other services to reconcile data that references deleted boards.
+//
+//encore:api private method=POST path=/internal/boards/missing
+func FindMissingBoards(ctx context.Context, p *FindMissingBoardsParams) (*FindMissingBoardsResponse, error) {
+	if len(p.BoardIDs) == 0 {
+		return &FindMissingBoardsResponse{}, nil
+	}
+
+	rows, err := boardDB.Query(ctx, `
+        SELECT id::text FROM boards
+        WHERE id = ANY($1::uuid[])
+    `, p.BoardIDs)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch boards").Cause(err).Err()
+	}
+	defer rows.Close()
+
+	existing := make(map[string]bool, len(p.BoardIDs))
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to scan board").Cause(err).Err()
+		}
+		existing[id] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("error reading boards").Cause(err).Err()
+	}
+
+	var missing []string
+	for _, id := range p.BoardIDs {
+		if !existing[id] {
+			missing = append(missing, id)
+		}
+	}
+
+	return &FindMissingBoardsResponse{Missing: missing}, nil
+}
@@ -0,0 +1,86 @@
+package board
+
+import (
+	"context"
+	"strings"
+
+	"encore.app/authz"
+	"encore.app/priority"
+	"encore.dev/beta/auth"
+	"encore.dev/beta/errs"
+	"encore.dev/storage/sqldb"
+)
+
+// DefaultPriorityResponse represents the priority assigned to new tasks of a board.
+type DefaultPriorityResponse struct {
+	BoardID         string `json:"board_id"`         // board id
+	DefaultPriority string `json:"default_priority"` // priority of new tasks that do not specify one
+}
+
+// GetDefaultPriority retrieves the default task priority of a board, restricted to users with
+// the board.view permission.
+//
+//encore:api auth method=GET path=/board/:boardID/default-priority
+func GetDefaultPriority(ctx context.Context, boardID string) (*DefaultPriorityResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if _, err := authorize(ctx, boardID, uid, authz.BoardView); err != nil {
+		return nil, err
+	}
+
+	resp := DefaultPriorityResponse{BoardID: boardID}
+	err := boardDB.QueryRow(ctx, `
+        SELECT default_priority
+        FROM boards
+        WHERE id = $1 AND deleted_at IS NULL
+    `, boardID).Scan(&resp.DefaultPriority)
+	if err != nil {
+		if err == sqldb.ErrNoRows {
+			return nil, errs.B().Code(errs.NotFound).Msg("board not found").Err()
+		}
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch board").Cause(err).Err()
+	}
+	return &resp, nil
+}
+
+// SetDefaultPriorityParams defines the default task priority of a board.
+type SetDefaultPriorityParams struct {
+	DefaultPriority string `json:"default_priority"` // one of "urgent", "high", "medium", "low" or "none"
+}
+
+// SetDefaultPriority sets the priority assigned to new tasks of a board that do not specify
+// one, restricted to users with the board.manage permission. Existing tasks are not changed.
+//
+//encore:api auth method=PUT path=/board/:boardID/default-priority
+func SetDefaultPriority(ctx context.Context, boardID string, p *SetDefaultPriorityParams) (*DefaultPriorityResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if _, err := authorize(ctx, boardID, uid, authz.BoardManage); err != nil {
+		return nil, err
+	}
+
+	if !priority.Valid(p.DefaultPriority) {
+		return nil, errs.B().Code(errs.InvalidArgument).
+			Msgf("default_priority must be one of: '%s'", strings.Join(priority.Levels, "', '")).Err()
+	}
+
+	result, err := boardDB.Exec(ctx, `
+        UPDATE boards
+        SET default_priority = $1
+        WHERE id = $2 AND deleted_at IS NULL
+    `, p.DefaultPriority, boardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to set default priority").Cause(err).Err()
+	}
+	if result.RowsAffected() == 0 {
+		return nil, errs.B().Code(errs.NotFound).Msg("board not found").Err()
+	}
+
+	return &DefaultPriorityResponse{BoardID: boardID, DefaultPriority: p.DefaultPriority}, nil
+}
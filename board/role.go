@@ -0,0 +1,416 @@
+package board
+
+import (
+	"context"
+	"strings"
+
+	"encore.app/authz"
+	"encore.dev/beta/auth"
+	"encore.dev/beta/errs"
+	"encore.dev/storage/sqldb"
+)
+
+// authorize checks that userID is a member of an active board and holds perm on it. It returns
+// the member's permissions, or a PermissionDenied error. Every board endpoint acting on a board
+// goes through it.
+func authorize(ctx context.Context, boardID string, userID auth.UID, perm string) ([]string, error) {
+	role, err := memberRole(ctx, boardID, string(userID))
+	if err != nil {
+		if err == sqldb.ErrNoRows {
+			return nil, errs.B().Code(errs.PermissionDenied).Msg("access denied: not a member of this board").Err()
+		}
+		return nil, errs.B().Code(errs.Internal).Msg("failed to check membership").Cause(err).Err()
+	}
+
+	return authorizeRole(ctx, boardID, role, perm)
+}
+
+// authorizeRole checks that role grants perm on a board, for callers that have already looked
+// up the member's role. It returns the role's permissions, or a PermissionDenied error.
+func authorizeRole(ctx context.Context, boardID, role, perm string) ([]string, error) {
+	perms, err := rolePermissions(ctx, boardID, role)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch role permissions").Cause(err).Err()
+	}
+	if err := authz.Require(perms, perm); err != nil {
+		return nil, err
+	}
+	return perms, nil
+}
+
+// rolePermissions returns the permissions granted by a built-in or custom role of a board.
+// A custom role that no longer exists grants no permissions.
+func rolePermissions(ctx context.Context, boardID, role string) ([]string, error) {
+	if authz.IsBuiltin(role) {
+		return authz.BuiltinPermissions(role), nil
+	}
+
+	var perms []string
+	err := boardDB.QueryRow(ctx, `
+        SELECT permissions FROM board_roles
+        WHERE board_id = $1 AND name = $2
+    `, boardID, role).Scan(&perms)
+	if err == sqldb.ErrNoRows {
+		return nil, nil
+	}
+	return perms, err
+}
+
+// validateAssignableRole checks that role can be given to a new or existing member: a built-in
+// role other than Admin (a board has a single Admin), or a custom role of the board.
+func validateAssignableRole(ctx context.Context, boardID, role string) error {
+	if role == authz.RoleAdmin {
+		return errs.B().Code(errs.InvalidArgument).Msg("the Admin role cannot be assigned").Err()
+	}
+	if authz.IsBuiltin(role) {
+		return nil
+	}
+
+	var exists bool
+	err := boardDB.QueryRow(ctx, `
+        SELECT EXISTS (
+            SELECT 1 FROM board_roles
+            WHERE board_id = $1 AND name = $2
+        )
+    `, boardID, role).Scan(&exists)
+	if err != nil {
+		return errs.B().Code(errs.Internal).Msg("failed to check role").Cause(err).Err()
+	}
+	if !exists {
+		return errs.B().Code(errs.InvalidArgument).Msg("role must be 'Member', 'Viewer', or a custom role of this board").Err()
+	}
+	return nil
+}
+
+// checkGrantable checks that a member holding granted may hand out perms: members can only
+// grant permissions they hold themselves.
+func checkGrantable(granted, perms []string) error {
+	if missing := authz.Missing(granted, perms); len(missing) > 0 {
+		return errs.B().Code(errs.PermissionDenied).Msgf("cannot grant permissions you do not have: '%s'", strings.Join(missing, "', '")).Err()
+	}
+	return nil
+}
+
+// checkGrantableRole checks that a member holding granted may give role to others, see
+// checkGrantable.
+func checkGrantableRole(ctx context.Context, boardID, role string, granted []string) error {
+	perms, err := rolePermissions(ctx, boardID, role)
+	if err != nil {
+		return errs.B().Code(errs.Internal).Msg("failed to fetch role permissions").Cause(err).Err()
+	}
+	return checkGrantable(granted, perms)
+}
+
+// RoleResponse represents a built-in or custom role of a board.
+type RoleResponse struct {
+	ID          string   `json:"id,omitempty"` // role id, empty for built-in roles
+	Name        string   `json:"name"`         // role name
+	Permissions []string `json:"permissions"`  // permissions granted by the role
+	Builtin     bool     `json:"builtin"`      // true for Admin, Member and Viewer
+}
+
+// ListRolesResponse represents the roles available on a board.
+type ListRolesResponse struct {
+	Roles []RoleResponse `json:"roles"`
+}
+
+// ListRoles retrieves the built-in and custom roles of a board, accessible only to its members.
+//
+//encore:api auth method=GET path=/board/:boardID/roles
+func ListRoles(ctx context.Context, boardID string) (*ListRolesResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if _, err := authorize(ctx, boardID, uid, authz.BoardView); err != nil {
+		return nil, err
+	}
+
+	roles := []RoleResponse{}
+	for _, name := range []string{authz.RoleAdmin, authz.RoleMember, authz.RoleViewer} {
+		roles = append(roles, RoleResponse{Name: name, Permissions: authz.BuiltinPermissions(name), Builtin: true})
+	}
+
+	rows, err := boardDB.Query(ctx, `
+        SELECT id, name, permissions
+        FROM board_roles
+        WHERE board_id = $1
+        ORDER BY name
+    `, boardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch roles").Cause(err).Err()
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r RoleResponse
+		if err := rows.Scan(&r.ID, &r.Name, &r.Permissions); err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to scan role").Cause(err).Err()
+		}
+		roles = append(roles, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("error reading roles").Cause(err).Err()
+	}
+
+	return &ListRolesResponse{Roles: roles}, nil
+}
+
+// RoleParams defines the input parameters for creating or updating a custom role.
+type RoleParams struct {
+	Name        string   `json:"name"`        // role name, ignored when updating
+	Permissions []string `json:"permissions"` // permissions granted by the role
+}
+
+// CreateRole creates a custom role on a board, restricted to users with the role.manage permission.
+// The role can only grant permissions the user holds.
+//
+//encore:api auth method=POST path=/board/:boardID/roles
+func CreateRole(ctx context.Context, boardID string, p *RoleParams) (*RoleResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	granted, err := authorize(ctx, boardID, uid, authz.RoleManage)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(p.Name)
+	if name == "" || len(name) > 50 {
+		return nil, errs.B().Code(errs.InvalidArgument).Msg("name is required and must be at most 50 characters").Err()
+	}
+	if authz.IsBuiltin(name) {
+		return nil, errs.B().Code(errs.AlreadyExists).Msg("role already exists").Err()
+	}
+	perms, err := validatePermissions(p.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkGrantable(granted, perms); err != nil {
+		return nil, err
+	}
+
+	var id string
+	err = boardDB.QueryRow(ctx, `
+        INSERT INTO board_roles (board_id, name, permissions)
+        VALUES ($1, $2, $3)
+        RETURNING id
+    `, boardID, name, perms).Scan(&id)
+	if err != nil {
+		if sqldb.ErrCode(err) == "23505" { // PostgreSQL unique violation
+			return nil, errs.B().Code(errs.AlreadyExists).Msg("role already exists").Err()
+		}
+		return nil, errs.B().Code(errs.Internal).Msg("failed to create role").Cause(err).Err()
+	}
+
+	return &RoleResponse{ID: id, Name: name, Permissions: perms}, nil
+}
+
+// UpdateRole replaces the permissions of a custom role, restricted to users with the
+// role.manage permission. Only roles whose current and new permissions the user holds can be
+// changed.
+//
+//encore:api auth method=PUT path=/board/:boardID/roles/:roleID
+func UpdateRole(ctx context.Context, boardID, roleID string, p *RoleParams) (*RoleResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	granted, err := authorize(ctx, boardID, uid, authz.RoleManage)
+	if err != nil {
+		return nil, err
+	}
+
+	perms, err := validatePermissions(p.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkGrantable(granted, perms); err != nil {
+		return nil, err
+	}
+
+	tx, err := boardDB.Begin(ctx)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to start transaction").Cause(err).Err()
+	}
+	defer tx.Rollback()
+
+	var name string
+	var current []string
+	err = tx.QueryRow(ctx, `
+        SELECT name, permissions FROM board_roles
+        WHERE id = $1 AND board_id = $2
+        FOR UPDATE
+    `, roleID, boardID).Scan(&name, &current)
+	if err != nil {
+		if err == sqldb.ErrNoRows {
+			return nil, errs.B().Code(errs.NotFound).Msg("role not found").Err()
+		}
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch role").Cause(err).Err()
+	}
+	// Taking permissions away from a role is as privileged as granting them.
+	if err := checkGrantable(granted, current); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, `
+        UPDATE board_roles
+        SET permissions = $1
+        WHERE id = $2
+    `, perms, roleID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to update role").Cause(err).Err()
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to commit transaction").Cause(err).Err()
+	}
+
+	return &RoleResponse{ID: roleID, Name: name, Permissions: perms}, nil
+}
+
+// DeleteRoleResponse represents the response when a custom role is deleted.
+type DeleteRoleResponse struct {
+	Message string `json:"message"`
+}
+
+// DeleteRole deletes a custom role that is not assigned to any member, restricted to users
+// with the role.manage permission.
+//
+//encore:api auth method=DELETE path=/board/:boardID/roles/:roleID
+func DeleteRole(ctx context.Context, boardID, roleID string) (*DeleteRoleResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if _, err := authorize(ctx, boardID, uid, authz.RoleManage); err != nil {
+		return nil, err
+	}
+
+	tx, err := boardDB.Begin(ctx)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to start transaction").Cause(err).Err()
+	}
+	defer tx.Rollback()
+
+	var name string
+	err = tx.QueryRow(ctx, `
+        DELETE FROM board_roles
+        WHERE id = $1 AND board_id = $2
+        RETURNING name
+    `, roleID, boardID).Scan(&name)
+	if err != nil {
+		if err == sqldb.ErrNoRows {
+			return nil, errs.B().Code(errs.NotFound).Msg("role not found").Err()
+		}
+		return nil, errs.B().Code(errs.Internal).Msg("failed to delete role").Cause(err).Err()
+	}
+
+	var inUse bool
+	err = tx.QueryRow(ctx, `
+        SELECT EXISTS (
+            SELECT 1 FROM board_members
+            WHERE board_id = $1 AND role = $2
+        )
+    `, boardID, name).Scan(&inUse)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to check role usage").Cause(err).Err()
+	}
+	if inUse {
+		return nil, errs.B().Code(errs.FailedPrecondition).Msg("role is assigned to board members").Err()
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to commit transaction").Cause(err).Err()
+	}
+
+	return &DeleteRoleResponse{Message: "Role deleted successfully"}, nil
+}
+
+// ChangeMemberRoleParams defines the new role of a board member.
+type ChangeMemberRoleParams struct {
+	Role string `json:"role"` // "Member", "Viewer", or a custom role of the board
+}
+
+// ChangeMemberRole changes the role of another board member, restricted to users with the
+// role.manage permission. The user must hold every permission of both the member's current and
+// new role. The Admin's role cannot be changed.
+//
+//encore:api auth method=PATCH path=/board/:boardID/user/:userID
+func ChangeMemberRole(ctx context.Context, boardID, userID string, p *ChangeMemberRoleParams) (*MemberResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	granted, err := authorize(ctx, boardID, uid, authz.RoleManage)
+	if err != nil {
+		return nil, err
+	}
+	if userID == string(uid) {
+		return nil, errs.B().Code(errs.PermissionDenied).Msg("cannot change your own role").Err()
+	}
+	if err := validateAssignableRole(ctx, boardID, p.Role); err != nil {
+		return nil, err
+	}
+	if err := checkGrantableRole(ctx, boardID, p.Role, granted); err != nil {
+		return nil, err
+	}
+
+	current, err := memberRole(ctx, boardID, userID)
+	if err != nil {
+		if err == sqldb.ErrNoRows {
+			return nil, errs.B().Code(errs.NotFound).Msg("user not a member of this board or is its Admin").Err()
+		}
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch member").Cause(err).Err()
+	}
+	if err := checkGrantableRole(ctx, boardID, current, granted); err != nil {
+		return nil, err
+	}
+
+	// The role is only replaced if it is still the one checked above.
+	result, err := boardDB.Exec(ctx, `
+        UPDATE board_members
+        SET role = $1
+        WHERE board_id = $2 AND user_id = $3 AND role = $4 AND role <> $5
+    `, p.Role, boardID, userID, current, authz.RoleAdmin)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to change role").Cause(err).Err()
+	}
+	if result.RowsAffected() == 0 {
+		return nil, errs.B().Code(errs.NotFound).Msg("user not a member of this board or is its Admin").Err()
+	}
+
+	return &MemberResponse{UserID: userID, Role: p.Role}, nil
+}
+
+// copyBoardRoles copies the custom roles of one board to another within tx.
+func copyBoardRoles(ctx context.Context, tx *sqldb.Tx, fromBoardID, toBoardID string) error {
+	_, err := tx.Exec(ctx, `
+        INSERT INTO board_roles (board_id, name, permissions)
+        SELECT $1, name, permissions
+        FROM board_roles
+        WHERE board_id = $2
+    `, toBoardID, fromBoardID)
+	return err
+}
+
+// validatePermissions checks a permission list and returns it without duplicates.
+func validatePermissions(perms []string) ([]string, error) {
+	result := []string{}
+	for _, perm := range perms {
+		if !authz.Valid(perm) {
+			return nil, errs.B().Code(errs.InvalidArgument).Msgf("unknown permission '%s'", perm).Err()
+		}
+		if !authz.Has(result, perm) {
+			result = append(result, perm)
+		}
+	}
+	return result, nil
+}
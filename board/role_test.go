@@ -0,0 +1,112 @@
+//go:build encore_app
+
+package board
+
+import (
+	"testing"
+
+	"encore.app/authz"
+	"encore.dev/beta/auth"
+	"encore.dev/beta/errs"
+)
+
+// newRoleManager adds a member whose custom role can manage roles and invite users but holds
+// no task permissions.
+func newRoleManager(t *testing.T, boardID string, admin auth.UID) auth.UID {
+	t.Helper()
+	_, err := CreateRole(as(admin), boardID, &RoleParams{
+		Name:        "Role Manager",
+		Permissions: []string{authz.BoardView, authz.RoleManage, authz.MemberInvite},
+	})
+	if err != nil {
+		t.Fatalf("CreateRole: %v", err)
+	}
+	return addMember(t, boardID, admin, "Role Manager")
+}
+
+func TestRoleManagerCannotEscalate(t *testing.T) {
+	boardID, admin := newBoard(t)
+	manager := newRoleManager(t, boardID, admin)
+	member := addMember(t, boardID, admin, authz.RoleMember)
+
+	tests := []struct {
+		name string
+		call func() error
+		want errs.ErrCode
+	}{
+		{
+			name: "create role with permissions the caller lacks",
+			call: func() error {
+				_, err := CreateRole(as(manager), boardID, &RoleParams{Name: "Deleter", Permissions: []string{authz.TaskDeleteAny}})
+				return err
+			},
+			want: errs.PermissionDenied,
+		},
+		{
+			name: "create role with the caller's permissions",
+			call: func() error {
+				_, err := CreateRole(as(manager), boardID, &RoleParams{Name: "Inviter", Permissions: []string{authz.BoardView, authz.MemberInvite}})
+				return err
+			},
+		},
+		{
+			name: "extend own role",
+			call: func() error {
+				roles, err := ListRoles(as(manager), boardID)
+				if err != nil {
+					return err
+				}
+				for _, r := range roles.Roles {
+					if r.Name == "Role Manager" {
+						_, err = UpdateRole(as(manager), boardID, r.ID, &RoleParams{Permissions: append(r.Permissions, authz.BoardDelete)})
+						return err
+					}
+				}
+				t.Fatal("role not found")
+				return nil
+			},
+			want: errs.PermissionDenied,
+		},
+		{
+			name: "change own role",
+			call: func() error {
+				_, err := ChangeMemberRole(as(manager), boardID, string(manager), &ChangeMemberRoleParams{Role: authz.RoleViewer})
+				return err
+			},
+			want: errs.PermissionDenied,
+		},
+		{
+			name: "change the role of a member with permissions the caller lacks",
+			call: func() error {
+				_, err := ChangeMemberRole(as(manager), boardID, string(member), &ChangeMemberRoleParams{Role: "Inviter"})
+				return err
+			},
+			want: errs.PermissionDenied,
+		},
+		{
+			name: "invite with a role granting permissions the caller lacks",
+			call: func() error {
+				_, err := InviteUser(as(manager), &InviteUserParams{BoardID: boardID, InviteeID: string(newUserID(t)), Role: authz.RoleMember})
+				return err
+			},
+			want: errs.PermissionDenied,
+		},
+		{
+			name: "create join link granting permissions the caller lacks",
+			call: func() error {
+				_, err := CreateJoinLink(as(manager), boardID, &CreateJoinLinkParams{Role: authz.RoleMember})
+				return err
+			},
+			want: errs.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if got := errs.Code(err); got != tt.want {
+				t.Errorf("got %v (%v), want %v", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,342 @@
+package board
+
+import (
+	"context"
+	"time"
+
+	"encore.app/authz"
+	"encore.dev/beta/auth"
+	"encore.dev/beta/errs"
+	"encore.dev/storage/sqldb"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Password attempts on a share are throttled: at most maxShareAttempts incorrect passwords are
+// reported as incorrect per shareAttemptWindow, across all viewers. Further incorrect passwords
+// are rejected as throttled, while the correct password is still accepted, so guessing cannot
+// lock viewers who know the password out.
+const (
+	maxShareAttempts   = 10
+	shareAttemptWindow = 15 * time.Minute
+)
+
+// ShareBoardParams defines the input parameters for publishing a board.
+type ShareBoardParams struct {
+	ExpiresAt string `json:"expires_at,omitempty"` // RFC3339 expiry time, empty for no expiry
+	Password  string `json:"password,omitempty"`   // password required to view the board, empty for none
+}
+
+// ShareResponse represents the public share of a board.
+type ShareResponse struct {
+	BoardID           string `json:"board_id"`             // board id
+	Token             string `json:"token"`                // secret token used in GET /public/board/:shareToken
+	ExpiresAt         string `json:"expires_at,omitempty"` // expiry time, empty if the share never expires
+	PasswordProtected bool   `json:"password_protected"`   // true if viewers must provide a password
+	CreatedBy         string `json:"created_by"`           // UID of the user who published the board
+	CreatedAt         string `json:"created_at"`           // time of publishing the board
+}
+
+// ShareBoard publishes a board as read-only to anyone with its share URL, restricted to users
+// with the board.manage permission. Sharing an already shared board updates its expiry and
+// password but keeps its token.
+//
+//encore:api auth method=PUT path=/board/:boardID/share
+func ShareBoard(ctx context.Context, boardID string, p *ShareBoardParams) (*ShareResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if _, err := authorize(ctx, boardID, uid, authz.BoardManage); err != nil {
+		return nil, err
+	}
+
+	var expiresAt *time.Time
+	if p.ExpiresAt != "" {
+		t, err := time.Parse(time.RFC3339, p.ExpiresAt)
+		if err != nil {
+			return nil, errs.B().Code(errs.InvalidArgument).Msg("expires_at must be an RFC3339 timestamp").Err()
+		}
+		if !t.After(time.Now()) {
+			return nil, errs.B().Code(errs.InvalidArgument).Msg("expires_at must be in the future").Err()
+		}
+		t = t.UTC()
+		expiresAt = &t
+	}
+
+	var passwordHash *string
+	if p.Password != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(p.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to hash password").Cause(err).Err()
+		}
+		h := string(hash)
+		passwordHash = &h
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to generate share token").Cause(err).Err()
+	}
+
+	rows, err := boardDB.Query(ctx, `
+        INSERT INTO board_shares (board_id, token, password_hash, expires_at, created_by)
+        VALUES ($1, $2, $3, $4, $5)
+        ON CONFLICT (board_id) DO UPDATE
+        SET password_hash = EXCLUDED.password_hash, expires_at = EXCLUDED.expires_at
+        RETURNING board_id, token, expires_at, password_hash IS NOT NULL, created_by, created_at
+    `, boardID, token, passwordHash, expiresAt, uid)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to share board").Cause(err).Err()
+	}
+	return scanShare(rows)
+}
+
+// GetShare retrieves the public share of a board, restricted to users with the board.manage
+// permission.
+//
+//encore:api auth method=GET path=/board/:boardID/share
+func GetShare(ctx context.Context, boardID string) (*ShareResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if _, err := authorize(ctx, boardID, uid, authz.BoardManage); err != nil {
+		return nil, err
+	}
+
+	rows, err := boardDB.Query(ctx, `
+        SELECT board_id, token, expires_at, password_hash IS NOT NULL, created_by, created_at
+        FROM board_shares
+        WHERE board_id = $1
+    `, boardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch share").Cause(err).Err()
+	}
+	return scanShare(rows)
+}
+
+// RotateShareToken replaces the token of a board's public share, invalidating the previous
+// URL, restricted to users with the board.manage permission.
+//
+//encore:api auth method=POST path=/board/:boardID/share/rotate
+func RotateShareToken(ctx context.Context, boardID string) (*ShareResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if _, err := authorize(ctx, boardID, uid, authz.BoardManage); err != nil {
+		return nil, err
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to generate share token").Cause(err).Err()
+	}
+
+	rows, err := boardDB.Query(ctx, `
+        UPDATE board_shares
+        SET token = $2
+        WHERE board_id = $1
+        RETURNING board_id, token, expires_at, password_hash IS NOT NULL, created_by, created_at
+    `, boardID, token)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to rotate share token").Cause(err).Err()
+	}
+	return scanShare(rows)
+}
+
+// UnshareBoardResponse represents the response when a board's public share is revoked.
+type UnshareBoardResponse struct {
+	Message string `json:"message"`
+}
+
+// UnshareBoard revokes the public share of a board, restricted to users with the board.manage
+// permission.
+//
+//encore:api auth method=DELETE path=/board/:boardID/share
+func UnshareBoard(ctx context.Context, boardID string) (*UnshareBoardResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if _, err := authorize(ctx, boardID, uid, authz.BoardManage); err != nil {
+		return nil, err
+	}
+
+	result, err := boardDB.Exec(ctx, `
+        DELETE FROM board_shares
+        WHERE board_id = $1
+    `, boardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to revoke share").Cause(err).Err()
+	}
+
+	if result.RowsAffected() == 0 {
+		return nil, errs.B().Code(errs.NotFound).Msg("board is not shared").Err()
+	}
+
+	return &UnshareBoardResponse{Message: "Board share revoked successfully"}, nil
+}
+
+// ResolveShareParams identifies a public share and the password provided by the viewer.
+type ResolveShareParams struct {
+	Token    string `json:"token"`              // share token from the public URL
+	Password string `json:"password,omitempty"` // password provided by the viewer
+}
+
+// SharedBoardResponse represents a publicly shared board, without member information.
+type SharedBoardResponse struct {
+	ID            string   `json:"id"`             // board id
+	Name          string   `json:"name"`           // board name
+	Description   string   `json:"description"`    // board description
+	Archived      bool     `json:"archived"`       // true if the board is archived
+	Stages        []string `json:"stages"`         // stages of the board in column order
+	StagesVersion int      `json:"stages_version"` // stage layout version, see GetStageLayout
+	CreatedAt     string   `json:"created_at"`     // time of creating the board
+}
+
+// ResolveShare returns the board published under a share token, checking its expiry and
+// password. Incorrect passwords are throttled per share, without rejecting the correct one. It
+// is used by task service to serve public board pages.
+//
+//encore:api private method=POST path=/internal/share/resolve
+func ResolveShare(ctx context.Context, p *ResolveShareParams) (*SharedBoardResponse, error) {
+	var resp SharedBoardResponse
+	var passwordHash *string
+	var createdAt time.Time
+	err := boardDB.QueryRow(ctx, `
+        SELECT b.id, b.name, b.description, b.archived_at IS NOT NULL, b.stages_version, b.created_at, s.password_hash
+        FROM board_shares s
+        JOIN boards b ON s.board_id = b.id
+        WHERE s.token = $1
+          AND (s.expires_at IS NULL OR s.expires_at > NOW())
+          AND b.deleted_at IS NULL
+    `, p.Token).Scan(&resp.ID, &resp.Name, &resp.Description, &resp.Archived, &resp.StagesVersion, &createdAt, &passwordHash)
+	if err != nil {
+		if err == sqldb.ErrNoRows {
+			return nil, errs.B().Code(errs.NotFound).Msg("share link is invalid, expired, or has been revoked").Err()
+		}
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch share").Cause(err).Err()
+	}
+
+	if passwordHash != nil {
+		if p.Password == "" {
+			return nil, errs.B().Code(errs.Unauthenticated).Msg("password required").Err()
+		}
+		attemptID, throttled, err := reserveShareAttempt(ctx, resp.ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := bcrypt.CompareHashAndPassword([]byte(*passwordHash), []byte(p.Password)); err != nil {
+			if throttled {
+				return nil, errs.B().Code(errs.ResourceExhausted).Msg("too many incorrect passwords; try again later").Err()
+			}
+			return nil, errs.B().Code(errs.PermissionDenied).Msg("incorrect password").Err()
+		}
+		// Only incorrect passwords count towards the limit.
+		if !throttled {
+			_, err = boardDB.Exec(ctx, `
+                DELETE FROM board_share_attempts
+                WHERE id = $1
+            `, attemptID)
+			if err != nil {
+				return nil, errs.B().Code(errs.Internal).Msg("failed to clear password attempt").Cause(err).Err()
+			}
+		}
+	}
+
+	resp.Stages, err = stageNames(ctx, resp.ID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch stages").Cause(err).Err()
+	}
+	resp.CreatedAt = createdAt.Format(time.RFC3339)
+
+	return &resp, nil
+}
+
+// reserveShareAttempt records a password attempt on the share of a board before the password
+// is checked, so concurrent guesses cannot exceed the limit together. It returns the attempt id,
+// or reports the attempt as throttled without recording it if the share has seen too many
+// attempts recently.
+func reserveShareAttempt(ctx context.Context, boardID string) (int64, bool, error) {
+	tx, err := boardDB.Begin(ctx)
+	if err != nil {
+		return 0, false, errs.B().Code(errs.Internal).Msg("failed to start transaction").Cause(err).Err()
+	}
+	defer tx.Rollback()
+
+	// Lock the share so attempts on it are counted one at a time.
+	_, err = tx.Exec(ctx, `
+        SELECT 1 FROM board_shares
+        WHERE board_id = $1
+        FOR UPDATE
+    `, boardID)
+	if err != nil {
+		return 0, false, errs.B().Code(errs.Internal).Msg("failed to lock share").Cause(err).Err()
+	}
+
+	window := shareAttemptWindow.Seconds()
+	_, err = tx.Exec(ctx, `
+        DELETE FROM board_share_attempts
+        WHERE board_id = $1 AND attempted_at <= NOW() - make_interval(secs => $2)
+    `, boardID, window)
+	if err != nil {
+		return 0, false, errs.B().Code(errs.Internal).Msg("failed to expire password attempts").Cause(err).Err()
+	}
+
+	var count int
+	err = tx.QueryRow(ctx, `
+        SELECT COUNT(*) FROM board_share_attempts
+        WHERE board_id = $1
+    `, boardID).Scan(&count)
+	if err != nil {
+		return 0, false, errs.B().Code(errs.Internal).Msg("failed to count password attempts").Cause(err).Err()
+	}
+	if count >= maxShareAttempts {
+		return 0, true, nil
+	}
+
+	var id int64
+	err = tx.QueryRow(ctx, `
+        INSERT INTO board_share_attempts (board_id)
+        VALUES ($1)
+        RETURNING id
+    `, boardID).Scan(&id)
+	if err != nil {
+		return 0, false, errs.B().Code(errs.Internal).Msg("failed to record password attempt").Cause(err).Err()
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, false, errs.B().Code(errs.Internal).Msg("failed to commit transaction").Cause(err).Err()
+	}
+	return id, false, nil
+}
+
+// scanShare scans the single board_shares row returned by rows, reporting NotFound if there
+// is none.
+func scanShare(rows *sqldb.Rows) (*ShareResponse, error) {
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("error reading share").Cause(err).Err()
+		}
+		return nil, errs.B().Code(errs.NotFound).Msg("board is not shared").Err()
+	}
+
+	var s ShareResponse
+	var expiresAt *time.Time
+	var createdAt time.Time
+	if err := rows.Scan(&s.BoardID, &s.Token, &expiresAt, &s.PasswordProtected, &s.CreatedBy, &createdAt); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to scan share").Cause(err).Err()
+	}
+	if expiresAt != nil {
+		s.ExpiresAt = expiresAt.Format(time.RFC3339)
+	}
+	s.CreatedAt = createdAt.Format(time.RFC3339)
+	return &s, nil
+}
@@ -0,0 +1,76 @@
+//go:build encore_app
+
+package board
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"encore.dev/beta/errs"
+)
+
+func TestResolveShareThrottlesPasswords(t *testing.T) {
+	boardID, admin := newBoard(t)
+	share, err := ShareBoard(as(admin), boardID, &ShareBoardParams{Password: "correct horse"})
+	if err != nil {
+		t.Fatalf("ShareBoard: %v", err)
+	}
+
+	if _, err := ResolveShare(as(admin), &ResolveShareParams{Token: share.Token, Password: "correct horse"}); err != nil {
+		t.Fatalf("ResolveShare: %v", err)
+	}
+
+	results := concurrently(maxShareAttempts+5, func(int) error {
+		_, err := ResolveShare(as(admin), &ResolveShareParams{Token: share.Token, Password: "wrong"})
+		return err
+	})
+	denied := 0
+	for _, err := range results {
+		switch errs.Code(err) {
+		case errs.PermissionDenied:
+			denied++
+		case errs.ResourceExhausted:
+		default:
+			t.Errorf("unexpected result: %v", err)
+		}
+	}
+	if denied != maxShareAttempts {
+		t.Errorf("%d passwords checked, want %d", denied, maxShareAttempts)
+	}
+
+	// Once throttled, incorrect passwords are rejected as such, but viewers who know the
+	// password are not locked out.
+	_, err = ResolveShare(as(admin), &ResolveShareParams{Token: share.Token, Password: "wrong"})
+	if errs.Code(err) != errs.ResourceExhausted {
+		t.Errorf("incorrect password: got %v, want ResourceExhausted", err)
+	}
+	if _, err := ResolveShare(as(admin), &ResolveShareParams{Token: share.Token, Password: "correct horse"}); err != nil {
+		t.Errorf("correct password: %v", err)
+	}
+}
+
+func TestResolveShareExpiry(t *testing.T) {
+	boardID, admin := newBoard(t)
+	expiresAt := time.Now().Add(time.Hour).Format(time.RFC3339)
+	share, err := ShareBoard(as(admin), boardID, &ShareBoardParams{ExpiresAt: expiresAt})
+	if err != nil {
+		t.Fatalf("ShareBoard: %v", err)
+	}
+	if _, err := ResolveShare(as(admin), &ResolveShareParams{Token: share.Token}); err != nil {
+		t.Fatalf("ResolveShare: %v", err)
+	}
+
+	// Once expired, the share no longer resolves.
+	_, err = boardDB.Exec(context.Background(), `
+        UPDATE board_shares SET expires_at = NOW() - INTERVAL '1 minute'
+        WHERE board_id = $1
+    `, boardID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = ResolveShare(as(admin), &ResolveShareParams{Token: share.Token})
+	if errs.Code(err) != errs.NotFound {
+		t.Errorf("expired share: got %v, want NotFound", err)
+	}
+}
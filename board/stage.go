@@ -0,0 +1,642 @@
+package board
+
+import (
+	"context"
+	"strings"
+
+	"encore.app/authz"
+	"encore.dev/beta/auth"
+	"encore.dev/beta/errs"
+	"encore.dev/pubsub"
+	"encore.dev/rlog"
+	"encore.dev/storage/sqldb"
+)
+
+// maxStageNameLength is the maximum length of a stage name.
+const maxStageNameLength = 50
+
+// defaultStages are the stages of a newly created board unless a template defines others.
+var defaultStages = []string{"To Do", "In Progress", "Done"}
+
+// StageChangedEvent represents an event published when a stage is renamed or deleted, used by
+// task service to bring the stages of the board's tasks in line with its stage layout. Task
+// service reads the layout itself, so events may arrive late, twice or out of order.
+type StageChangedEvent struct {
+	BoardID string `json:"board_id"` // board id
+	Version int    `json:"version"`  // stage layout version after the change
+}
+
+// StageChangedTopic is a Pub/Sub topic for notifying task service
+// when the stages of a board were renamed or deleted.
+var StageChangedTopic = pubsub.NewTopic[*StageChangedEvent]("stage-changed", pubsub.TopicConfig{
+	DeliveryGuarantee: pubsub.AtLeastOnce,
+})
+
+// StageResponse represents a single workflow stage of a board.
+type StageResponse struct {
+	ID       string `json:"id"`                  // stage id
+	Name     string `json:"name"`                // stage name
+	Position int    `json:"position"`            // 0-based column order
+	WIPLimit int    `json:"wip_limit,omitempty"` // maximum number of tasks in the stage, 0 for no limit
+	WIPMode  string `json:"wip_mode"`            // "hard" blocks tasks over the limit, "soft" only warns
+}
+
+// ListStagesResponse represents the ordered stages of a board.
+type ListStagesResponse struct {
+	Stages []StageResponse `json:"stages"`
+}
+
+// ListStages retrieves the ordered stages of a board, accessible only to its members.
+//
+//encore:api auth method=GET path=/board/:boardID/stages
+func ListStages(ctx context.Context, boardID string) (*ListStagesResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if _, err := authorize(ctx, boardID, uid, authz.BoardView); err != nil {
+		return nil, err
+	}
+
+	stages, err := listStages(ctx, boardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch stages").Cause(err).Err()
+	}
+
+	return &ListStagesResponse{Stages: stages}, nil
+}
+
+// ListStageLimits retrieves the ordered stages of a board with their WIP limits without
+// checking membership. It is used by task service to enforce WIP limits and summarize columns.
+//
+//encore:api private method=GET path=/internal/board/:boardID/stages
+func ListStageLimits(ctx context.Context, boardID string) (*ListStagesResponse, error) {
+	stages, err := listStages(ctx, boardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch stages").Cause(err).Err()
+	}
+
+	return &ListStagesResponse{Stages: stages}, nil
+}
+
+// AddStageParams defines the input parameters for adding a stage to a board.
+type AddStageParams struct {
+	Name     string `json:"name"`               // stage name
+	Position *int   `json:"position,omitempty"` // 0-based position, defaults to the last column
+}
+
+// AddStage adds a stage to a board, restricted to users with the board.manage permission.
+//
+//encore:api auth method=POST path=/board/:boardID/stages
+func AddStage(ctx context.Context, boardID string, p *AddStageParams) (*ListStagesResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if _, err := authorize(ctx, boardID, uid, authz.BoardManage); err != nil {
+		return nil, err
+	}
+
+	name, err := validateStageName(p.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := boardDB.Begin(ctx)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to start transaction").Cause(err).Err()
+	}
+	defer tx.Rollback()
+
+	count, err := lockStages(ctx, tx, boardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch stages").Cause(err).Err()
+	}
+
+	position := count
+	if p.Position != nil {
+		if *p.Position < 0 || *p.Position > count {
+			return nil, errs.B().Code(errs.InvalidArgument).Msg("position out of range").Err()
+		}
+		position = *p.Position
+	}
+
+	_, err = tx.Exec(ctx, `
+        UPDATE board_stages
+        SET position = position + 1
+        WHERE board_id = $1 AND position >= $2
+    `, boardID, position)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to reorder stages").Cause(err).Err()
+	}
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO board_stages (board_id, name, position)
+        VALUES ($1, $2, $3)
+    `, boardID, name, position)
+	if err != nil {
+		if sqldb.ErrCode(err) == "23505" { // PostgreSQL unique violation
+			return nil, errs.B().Code(errs.AlreadyExists).Msg("stage already exists").Err()
+		}
+		return nil, errs.B().Code(errs.Internal).Msg("failed to add stage").Cause(err).Err()
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to commit transaction").Cause(err).Err()
+	}
+
+	stages, err := listStages(ctx, boardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch stages").Cause(err).Err()
+	}
+	return &ListStagesResponse{Stages: stages}, nil
+}
+
+// RenameStageParams defines the input parameters for renaming a stage.
+type RenameStageParams struct {
+	Name string `json:"name"` // new stage name
+}
+
+// RenameStage renames a stage of a board, restricted to users with the board.manage permission.
+// Tasks in the stage follow it by id; task service updates the stage name recorded on them.
+//
+//encore:api auth method=PATCH path=/board/:boardID/stages/:stageID
+func RenameStage(ctx context.Context, boardID, stageID string, p *RenameStageParams) (*ListStagesResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if _, err := authorize(ctx, boardID, uid, authz.BoardManage); err != nil {
+		return nil, err
+	}
+
+	name, err := validateStageName(p.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := boardDB.Begin(ctx)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to start transaction").Cause(err).Err()
+	}
+	defer tx.Rollback()
+
+	var oldName string
+	err = tx.QueryRow(ctx, `
+        SELECT name FROM board_stages
+        WHERE id = $1 AND board_id = $2
+        FOR UPDATE
+    `, stageID, boardID).Scan(&oldName)
+	if err != nil {
+		if err == sqldb.ErrNoRows {
+			return nil, errs.B().Code(errs.NotFound).Msg("stage not found").Err()
+		}
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch stage").Cause(err).Err()
+	}
+
+	var eventID int64
+	if oldName != name {
+		_, err = tx.Exec(ctx, `
+            UPDATE board_stages
+            SET name = $1
+            WHERE id = $2
+        `, name, stageID)
+		if err != nil {
+			if sqldb.ErrCode(err) == "23505" { // PostgreSQL unique violation
+				return nil, errs.B().Code(errs.AlreadyExists).Msg("stage already exists").Err()
+			}
+			return nil, errs.B().Code(errs.Internal).Msg("failed to rename stage").Cause(err).Err()
+		}
+
+		eventID, err = recordStageChange(ctx, tx, boardID)
+		if err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to record stage change").Cause(err).Err()
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to commit transaction").Cause(err).Err()
+	}
+	publishStageChange(ctx, boardID, eventID)
+
+	stages, err := listStages(ctx, boardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch stages").Cause(err).Err()
+	}
+	return &ListStagesResponse{Stages: stages}, nil
+}
+
+// ReorderStagesParams defines the new order of a board's stages.
+type ReorderStagesParams struct {
+	StageIDs []string `json:"stage_ids"` // all stage ids of the board in their new order
+}
+
+// ReorderStages changes the column order of a board's stages, restricted to users with the
+// board.manage permission.
+//
+//encore:api auth method=PUT path=/board/:boardID/stages
+func ReorderStages(ctx context.Context, boardID string, p *ReorderStagesParams) (*ListStagesResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if _, err := authorize(ctx, boardID, uid, authz.BoardManage); err != nil {
+		return nil, err
+	}
+
+	tx, err := boardDB.Begin(ctx)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to start transaction").Cause(err).Err()
+	}
+	defer tx.Rollback()
+
+	count, err := lockStages(ctx, tx, boardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch stages").Cause(err).Err()
+	}
+	if len(p.StageIDs) != count {
+		return nil, errs.B().Code(errs.InvalidArgument).Msg("stage_ids must list every stage of the board exactly once").Err()
+	}
+
+	seen := make(map[string]bool, len(p.StageIDs))
+	for position, id := range p.StageIDs {
+		if seen[id] {
+			return nil, errs.B().Code(errs.InvalidArgument).Msg("stage_ids must list every stage of the board exactly once").Err()
+		}
+		seen[id] = true
+
+		result, err := tx.Exec(ctx, `
+            UPDATE board_stages
+            SET position = $1
+            WHERE id = $2 AND board_id = $3
+        `, position, id, boardID)
+		if err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to reorder stages").Cause(err).Err()
+		}
+		if result.RowsAffected() == 0 {
+			return nil, errs.B().Code(errs.InvalidArgument).Msg("stage_ids must list every stage of the board exactly once").Err()
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to commit transaction").Cause(err).Err()
+	}
+
+	stages, err := listStages(ctx, boardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch stages").Cause(err).Err()
+	}
+	return &ListStagesResponse{Stages: stages}, nil
+}
+
+// SetWIPLimitParams defines the work-in-progress limit of a stage.
+type SetWIPLimitParams struct {
+	Limit int    `json:"limit"`          // maximum number of tasks in the stage, 0 removes the limit
+	Mode  string `json:"mode,omitempty"` // "hard" (default) blocks tasks over the limit, "soft" only warns
+}
+
+// SetWIPLimit sets or removes the work-in-progress limit of a stage, restricted to users with
+// the board.manage permission.
+//
+//encore:api auth method=PUT path=/board/:boardID/stages/:stageID/wip-limit
+func SetWIPLimit(ctx context.Context, boardID, stageID string, p *SetWIPLimitParams) (*ListStagesResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if _, err := authorize(ctx, boardID, uid, authz.BoardManage); err != nil {
+		return nil, err
+	}
+
+	if p.Limit < 0 {
+		return nil, errs.B().Code(errs.InvalidArgument).Msg("limit must be non-negative").Err()
+	}
+	mode := p.Mode
+	if mode == "" {
+		mode = "hard"
+	}
+	if mode != "hard" && mode != "soft" {
+		return nil, errs.B().Code(errs.InvalidArgument).Msg("mode must be 'hard' or 'soft'").Err()
+	}
+
+	result, err := boardDB.Exec(ctx, `
+        UPDATE board_stages
+        SET wip_limit = NULLIF($1, 0), wip_mode = $2
+        WHERE id = $3 AND board_id = $4
+    `, p.Limit, mode, stageID, boardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to set WIP limit").Cause(err).Err()
+	}
+	if result.RowsAffected() == 0 {
+		return nil, errs.B().Code(errs.NotFound).Msg("stage not found").Err()
+	}
+
+	stages, err := listStages(ctx, boardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch stages").Cause(err).Err()
+	}
+	return &ListStagesResponse{Stages: stages}, nil
+}
+
+// DeleteStageParams defines where the tasks of a deleted stage are moved.
+type DeleteStageParams struct {
+	MoveTo string `query:"move_to"` // id of the stage receiving the tasks, defaults to the first remaining stage
+}
+
+// DeleteStage deletes a stage of a board, restricted to users with the board.manage permission.
+// Tasks in the stage are moved to another stage by task service, which follows the redirect
+// recorded for the deleted stage. The last stage of a board cannot be deleted.
+//
+//encore:api auth method=DELETE path=/board/:boardID/stages/:stageID
+func DeleteStage(ctx context.Context, boardID, stageID string, p *DeleteStageParams) (*ListStagesResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if _, err := authorize(ctx, boardID, uid, authz.BoardManage); err != nil {
+		return nil, err
+	}
+
+	tx, err := boardDB.Begin(ctx)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to start transaction").Cause(err).Err()
+	}
+	defer tx.Rollback()
+
+	count, err := lockStages(ctx, tx, boardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch stages").Cause(err).Err()
+	}
+	if count <= 1 {
+		return nil, errs.B().Code(errs.FailedPrecondition).Msg("cannot delete the last stage of a board").Err()
+	}
+
+	var position int
+	err = tx.QueryRow(ctx, `
+        SELECT position FROM board_stages
+        WHERE id = $1 AND board_id = $2
+    `, stageID, boardID).Scan(&position)
+	if err != nil {
+		if err == sqldb.ErrNoRows {
+			return nil, errs.B().Code(errs.NotFound).Msg("stage not found").Err()
+		}
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch stage").Cause(err).Err()
+	}
+
+	var target string
+	if p.MoveTo != "" {
+		err = tx.QueryRow(ctx, `
+            SELECT id FROM board_stages
+            WHERE id = $1 AND board_id = $2 AND id <> $3
+        `, p.MoveTo, boardID, stageID).Scan(&target)
+	} else {
+		err = tx.QueryRow(ctx, `
+            SELECT id FROM board_stages
+            WHERE board_id = $1 AND id <> $2
+            ORDER BY position
+            LIMIT 1
+        `, boardID, stageID).Scan(&target)
+	}
+	if err != nil {
+		if err == sqldb.ErrNoRows {
+			return nil, errs.B().Code(errs.InvalidArgument).Msg("move_to must be another stage of this board").Err()
+		}
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch target stage").Cause(err).Err()
+	}
+
+	// Stages whose tasks were sent to the deleted stage now send them on to its target, so
+	// redirects never point to a stage that no longer exists.
+	_, err = tx.Exec(ctx, `
+        UPDATE board_stage_redirects
+        SET moved_to = $1
+        WHERE moved_to = $2
+    `, target, stageID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to forward stage redirects").Cause(err).Err()
+	}
+	_, err = tx.Exec(ctx, `
+        INSERT INTO board_stage_redirects (stage_id, board_id, moved_to)
+        VALUES ($1, $2, $3)
+    `, stageID, boardID, target)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to record stage redirect").Cause(err).Err()
+	}
+
+	_, err = tx.Exec(ctx, `
+        DELETE FROM board_stages
+        WHERE id = $1
+    `, stageID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to delete stage").Cause(err).Err()
+	}
+
+	_, err = tx.Exec(ctx, `
+        UPDATE board_stages
+        SET position = position - 1
+        WHERE board_id = $1 AND position > $2
+    `, boardID, position)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to reorder stages").Cause(err).Err()
+	}
+
+	eventID, err := recordStageChange(ctx, tx, boardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to record stage change").Cause(err).Err()
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to commit transaction").Cause(err).Err()
+	}
+	publishStageChange(ctx, boardID, eventID)
+
+	stages, err := listStages(ctx, boardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch stages").Cause(err).Err()
+	}
+	return &ListStagesResponse{Stages: stages}, nil
+}
+
+// listStages returns the stages of a board in column order.
+func listStages(ctx context.Context, boardID string) ([]StageResponse, error) {
+	rows, err := boardDB.Query(ctx, `
+        SELECT id, name, position, COALESCE(wip_limit, 0), wip_mode
+        FROM board_stages
+        WHERE board_id = $1
+        ORDER BY position
+    `, boardID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stages []StageResponse
+	for rows.Next() {
+		var s StageResponse
+		if err := rows.Scan(&s.ID, &s.Name, &s.Position, &s.WIPLimit, &s.WIPMode); err != nil {
+			return nil, err
+		}
+		stages = append(stages, s)
+	}
+	return stages, rows.Err()
+}
+
+// stageNames returns the stage names of a board in column order.
+func stageNames(ctx context.Context, boardID string) ([]string, error) {
+	stages, err := listStages(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(stages))
+	for i, s := range stages {
+		names[i] = s.Name
+	}
+	return names, nil
+}
+
+// lockStages locks the stages of a board within tx and returns how many there are.
+func lockStages(ctx context.Context, tx *sqldb.Tx, boardID string) (int, error) {
+	rows, err := tx.Query(ctx, `
+        SELECT id FROM board_stages
+        WHERE board_id = $1
+        FOR UPDATE
+    `, boardID)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		count++
+	}
+	return count, rows.Err()
+}
+
+// insertStages creates the given stages, in order, for a board within tx.
+func insertStages(ctx context.Context, tx *sqldb.Tx, boardID string, names []string) error {
+	for position, name := range names {
+		_, err := tx.Exec(ctx, `
+            INSERT INTO board_stages (board_id, name, position)
+            VALUES ($1, $2, $3)
+        `, boardID, name, position)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateStageName trims a stage name and checks it is non-empty and not too long.
+func validateStageName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errs.B().Code(errs.InvalidArgument).Msg("stage name is required").Err()
+	}
+	if len(name) > maxStageNameLength {
+		return "", errs.B().Code(errs.InvalidArgument).Msg("stage name must be at most 50 characters").Err()
+	}
+	return name, nil
+}
+
+// StageRef identifies a stage of a board.
+type StageRef struct {
+	ID   string `json:"id"`   // stage id
+	Name string `json:"name"` // stage name
+}
+
+// StageRedirect records that the tasks of a deleted stage moved to another stage.
+type StageRedirect struct {
+	From string `json:"from"` // id of the deleted stage
+	To   string `json:"to"`   // id of the existing stage that received its tasks
+}
+
+// StageLayoutResponse represents the current stages of a board and where the tasks of its
+// deleted stages went.
+type StageLayoutResponse struct {
+	Version   int             `json:"version"`   // stage layout version, incremented on every rename or deletion
+	Stages    []StageRef      `json:"stages"`    // stages in column order
+	Redirects []StageRedirect `json:"redirects"` // deleted stages of the board
+}
+
+// GetStageLayout retrieves the stage layout of a board, including boards in the trash. It is
+// used by task service to keep the stages recorded on tasks in line with the board.
+//
+//encore:api private method=GET path=/internal/board/:boardID/stage-layout
+func GetStageLayout(ctx context.Context, boardID string) (*StageLayoutResponse, error) {
+	resp := &StageLayoutResponse{Stages: []StageRef{}, Redirects: []StageRedirect{}}
+	err := boardDB.QueryRow(ctx, `
+        SELECT stages_version FROM boards
+        WHERE id = $1
+    `, boardID).Scan(&resp.Version)
+	if err != nil {
+		if err == sqldb.ErrNoRows {
+			return nil, errs.B().Code(errs.NotFound).Msg("board not found").Err()
+		}
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch board").Cause(err).Err()
+	}
+
+	stages, err := listStages(ctx, boardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch stages").Cause(err).Err()
+	}
+	for _, s := range stages {
+		resp.Stages = append(resp.Stages, StageRef{ID: s.ID, Name: s.Name})
+	}
+
+	rows, err := boardDB.Query(ctx, `
+        SELECT stage_id, moved_to
+        FROM board_stage_redirects
+        WHERE board_id = $1
+    `, boardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch stage redirects").Cause(err).Err()
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r StageRedirect
+		if err := rows.Scan(&r.From, &r.To); err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to scan stage redirect").Cause(err).Err()
+		}
+		resp.Redirects = append(resp.Redirects, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("error reading stage redirects").Cause(err).Err()
+	}
+
+	return resp, nil
+}
+
+// recordStageChange increments the stage layout version of a board within tx and writes the
+// stage change event to the outbox. It returns the outbox event id.
+func recordStageChange(ctx context.Context, tx *sqldb.Tx, boardID string) (int64, error) {
+	var version int
+	err := tx.QueryRow(ctx, `
+        UPDATE boards
+        SET stages_version = stages_version + 1
+        WHERE id = $1
+        RETURNING stages_version
+    `, boardID).Scan(&version)
+	if err != nil {
+		return 0, err
+	}
+	return enqueueEvent(ctx, tx, "stage-changed", &StageChangedEvent{BoardID: boardID, Version: version})
+}
+
+// publishStageChange publishes a recorded stage change event right away; if this fails the
+// outbox relay retries the event later.
+func publishStageChange(ctx context.Context, boardID string, eventID int64) {
+	if eventID == 0 {
+		return
+	}
+	if _, _, err := relayOutbox(ctx, eventID); err != nil {
+		rlog.Warn("failed to publish stage change event, leaving it to the outbox relay", "board_id", boardID, "err", err)
+	}
+}
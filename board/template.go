@@ -0,0 +1,255 @@
+package board
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"encore.dev/beta/auth"
+	"encore.dev/beta/errs"
+	"encore.dev/storage/sqldb"
+)
+
+// labelColorPattern matches the hex colors labels can have, e.g. "#d73a4a".
+var labelColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// TemplateLabel is a label predefined by a board template.
+type TemplateLabel struct {
+	Name  string `json:"name"`            // label name, unique per template ignoring case
+	Color string `json:"color,omitempty"` // label color, e.g. "#ff0000"; task service picks one if empty
+}
+
+// StarterTask is a task created on every board instantiated from a template.
+type StarterTask struct {
+	Title       string `json:"title"`                 // task title
+	Description string `json:"description,omitempty"` // task description (optional)
+	Stage       string `json:"stage,omitempty"`       // task stage, defaults to the first stage
+}
+
+// CreateTemplateParams defines the input parameters for saving a board template.
+type CreateTemplateParams struct {
+	Name        string          `json:"name"`                  // template name
+	Description string          `json:"description,omitempty"` // description of boards created from the template
+	Stages      []string        `json:"stages,omitempty"`      // ordered stages, defaults to 'To Do', 'In Progress', 'Done'
+	Labels      []TemplateLabel `json:"labels,omitempty"`      // predefined labels
+	Tasks       []StarterTask   `json:"tasks,omitempty"`       // starter tasks
+}
+
+// TemplateResponse represents a saved board template.
+type TemplateResponse struct {
+	ID          string          `json:"id"`          // template id
+	Name        string          `json:"name"`        // template name
+	Description string          `json:"description"` // description of boards created from the template
+	CreatedBy   string          `json:"created_by"`  // UID of template owner
+	Stages      []string        `json:"stages"`      // ordered stages
+	Labels      []TemplateLabel `json:"labels"`      // predefined labels
+	Tasks       []StarterTask   `json:"tasks"`       // starter tasks
+	CreatedAt   string          `json:"created_at"`  // time of creating the template
+}
+
+// CreateTemplate saves a board template owned by the authenticated user.
+//
+//encore:api auth method=POST path=/templates
+func CreateTemplate(ctx context.Context, p *CreateTemplateParams) (*TemplateResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if p.Name == "" {
+		return nil, errs.B().Code(errs.InvalidArgument).Msg("name is required").Err()
+	}
+
+	stages := slices.Clone(p.Stages)
+	if len(stages) == 0 {
+		stages = slices.Clone(defaultStages)
+	}
+	seen := make(map[string]bool, len(stages))
+	for i := range stages {
+		name, err := validateStageName(stages[i])
+		if err != nil {
+			return nil, err
+		}
+		if seen[name] {
+			return nil, errs.B().Code(errs.InvalidArgument).Msg("stage names must be unique").Err()
+		}
+		seen[name] = true
+		stages[i] = name
+	}
+	labels := slices.Clone(p.Labels)
+	if labels == nil {
+		labels = []TemplateLabel{}
+	}
+	seenLabels := make(map[string]bool, len(labels))
+	for i := range labels {
+		name := strings.TrimSpace(labels[i].Name)
+		if name == "" || len(name) > 50 {
+			return nil, errs.B().Code(errs.InvalidArgument).Msg("label name is required and must be at most 50 characters").Err()
+		}
+		if seenLabels[strings.ToLower(name)] {
+			return nil, errs.B().Code(errs.InvalidArgument).Msg("label names must be unique").Err()
+		}
+		seenLabels[strings.ToLower(name)] = true
+		if labels[i].Color != "" && !labelColorPattern.MatchString(labels[i].Color) {
+			return nil, errs.B().Code(errs.InvalidArgument).Msg("label color must be a hex color, e.g. '#d73a4a'").Err()
+		}
+		labels[i] = TemplateLabel{Name: name, Color: strings.ToLower(labels[i].Color)}
+	}
+	tasks := p.Tasks
+	if tasks == nil {
+		tasks = []StarterTask{}
+	}
+	for i := range tasks {
+		if tasks[i].Title == "" {
+			return nil, errs.B().Code(errs.InvalidArgument).Msg("starter task title is required").Err()
+		}
+		if tasks[i].Stage == "" {
+			tasks[i].Stage = stages[0]
+		}
+		if !slices.Contains(stages, tasks[i].Stage) {
+			return nil, errs.B().Code(errs.InvalidArgument).Msg("starter task stage must be one of the template stages").Err()
+		}
+	}
+
+	stagesJSON, _ := json.Marshal(stages)
+	labelsJSON, _ := json.Marshal(labels)
+	tasksJSON, _ := json.Marshal(tasks)
+
+	var id string
+	var createdAt time.Time
+	err := boardDB.QueryRow(ctx, `
+        INSERT INTO board_templates (name, description, created_by, stages, labels, tasks)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING id, created_at
+    `, p.Name, p.Description, uid, stagesJSON, labelsJSON, tasksJSON).Scan(&id, &createdAt)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to create template").Cause(err).Err()
+	}
+
+	return &TemplateResponse{
+		ID:          id,
+		Name:        p.Name,
+		Description: p.Description,
+		CreatedBy:   string(uid),
+		Stages:      stages,
+		Labels:      labels,
+		Tasks:       tasks,
+		CreatedAt:   createdAt.Format(time.RFC3339),
+	}, nil
+}
+
+// ListTemplatesResponse represents the templates of the authenticated user.
+type ListTemplatesResponse struct {
+	Templates []TemplateResponse `json:"templates"`
+}
+
+// ListTemplates retrieves all board templates owned by the authenticated user.
+//
+//encore:api auth method=GET path=/templates
+func ListTemplates(ctx context.Context) (*ListTemplatesResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	rows, err := boardDB.Query(ctx, `
+        SELECT id, name, description, created_by, stages, labels, tasks, created_at
+        FROM board_templates
+        WHERE created_by = $1
+        ORDER BY created_at DESC
+    `, uid)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch templates").Cause(err).Err()
+	}
+	defer rows.Close()
+
+	var templates []TemplateResponse
+	for rows.Next() {
+		t, err := scanTemplate(rows)
+		if err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to scan template").Cause(err).Err()
+		}
+		templates = append(templates, *t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("error reading templates").Cause(err).Err()
+	}
+
+	return &ListTemplatesResponse{Templates: templates}, nil
+}
+
+// DeleteTemplateResponse represents the response when a template is deleted.
+type DeleteTemplateResponse struct {
+	Message string `json:"message"`
+}
+
+// DeleteTemplate deletes a board template, restricted to its owner.
+//
+//encore:api auth method=DELETE path=/templates/:templateID
+func DeleteTemplate(ctx context.Context, templateID string) (*DeleteTemplateResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	result, err := boardDB.Exec(ctx, `
+        DELETE FROM board_templates
+        WHERE id = $1 AND created_by = $2
+    `, templateID, uid)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to delete template").Cause(err).Err()
+	}
+
+	if result.RowsAffected() == 0 {
+		return nil, errs.B().Code(errs.NotFound).Msg("template not found").Err()
+	}
+
+	return &DeleteTemplateResponse{Message: "Template deleted successfully"}, nil
+}
+
+// getTemplate fetches a template owned by userID. It returns sqldb.ErrNoRows if the template
+// does not exist or belongs to another user.
+func getTemplate(ctx context.Context, templateID, userID string) (*TemplateResponse, error) {
+	rows, err := boardDB.Query(ctx, `
+        SELECT id, name, description, created_by, stages, labels, tasks, created_at
+        FROM board_templates
+        WHERE id = $1 AND created_by = $2
+    `, templateID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, sqldb.ErrNoRows
+	}
+	return scanTemplate(rows)
+}
+
+// scanTemplate scans a board_templates row selected in the column order used above.
+func scanTemplate(rows *sqldb.Rows) (*TemplateResponse, error) {
+	var t TemplateResponse
+	var stagesJSON, labelsJSON, tasksJSON []byte
+	var createdAt time.Time
+	if err := rows.Scan(&t.ID, &t.Name, &t.Description, &t.CreatedBy, &stagesJSON, &labelsJSON, &tasksJSON, &createdAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(stagesJSON, &t.Stages); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(labelsJSON, &t.Labels); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(tasksJSON, &t.Tasks); err != nil {
+		return nil, err
+	}
+	t.CreatedAt = createdAt.Format(time.RFC3339)
+	return &t, nil
+}
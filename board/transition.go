@@ -0,0 +1,158 @@
+package board
+
+import (
+	"context"
+	"slices"
+
+	"encore.app/authz"
+	"encore.dev/beta/auth"
+	"encore.dev/beta/errs"
+)
+
+// transitionFields are the task fields a transition can require to be set.
+var transitionFields = []string{"assignee_id", "description"}
+
+// TransitionRule allows tasks to move from one stage to another, optionally with requirements.
+type TransitionRule struct {
+	From           string   `json:"from"`                      // source stage name
+	To             string   `json:"to"`                        // target stage name
+	AdminOnly      bool     `json:"admin_only,omitempty"`      // only users with the task.move.restricted permission (Admins by default) may perform the move
+	RequiredFields []string `json:"required_fields,omitempty"` // task fields that must be set: "assignee_id" (at least one assignee), "description"
+}
+
+// ListTransitionsResponse represents the transition rules of a board. An empty list means
+// tasks may move freely between stages.
+type ListTransitionsResponse struct {
+	Transitions []TransitionRule `json:"transitions"`
+}
+
+// ListTransitions retrieves the stage transition rules of a board, accessible only to its members.
+//
+//encore:api auth method=GET path=/board/:boardID/transitions
+func ListTransitions(ctx context.Context, boardID string) (*ListTransitionsResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if _, err := authorize(ctx, boardID, uid, authz.BoardView); err != nil {
+		return nil, err
+	}
+
+	return listTransitions(ctx, boardID)
+}
+
+// ListTransitionRules retrieves the stage transition rules of a board without checking
+// membership. It is used by task service to enforce the rules when tasks change stage.
+//
+//encore:api private method=GET path=/internal/board/:boardID/transitions
+func ListTransitionRules(ctx context.Context, boardID string) (*ListTransitionsResponse, error) {
+	return listTransitions(ctx, boardID)
+}
+
+// listTransitions returns the transition rules of a board ordered by stage position.
+func listTransitions(ctx context.Context, boardID string) (*ListTransitionsResponse, error) {
+	rows, err := boardDB.Query(ctx, `
+        SELECT f.name, t.name, r.admin_only, r.required_fields
+        FROM board_stage_transitions r
+        JOIN board_stages f ON f.id = r.from_stage_id
+        JOIN board_stages t ON t.id = r.to_stage_id
+        WHERE r.board_id = $1
+        ORDER BY f.position, t.position
+    `, boardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch transitions").Cause(err).Err()
+	}
+	defer rows.Close()
+
+	var transitions []TransitionRule
+	for rows.Next() {
+		var r TransitionRule
+		if err := rows.Scan(&r.From, &r.To, &r.AdminOnly, &r.RequiredFields); err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to scan transition").Cause(err).Err()
+		}
+		transitions = append(transitions, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("error reading transitions").Cause(err).Err()
+	}
+
+	return &ListTransitionsResponse{Transitions: transitions}, nil
+}
+
+// SetTransitionsParams defines the complete set of transition rules of a board.
+type SetTransitionsParams struct {
+	Transitions []TransitionRule `json:"transitions"` // an empty list removes all restrictions
+}
+
+// SetTransitions replaces the stage transition rules of a board, restricted to users with the
+// board.manage permission.
+//
+//encore:api auth method=PUT path=/board/:boardID/transitions
+func SetTransitions(ctx context.Context, boardID string, p *SetTransitionsParams) (*ListTransitionsResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if _, err := authorize(ctx, boardID, uid, authz.BoardManage); err != nil {
+		return nil, err
+	}
+
+	for _, r := range p.Transitions {
+		if r.From == r.To {
+			return nil, errs.B().Code(errs.InvalidArgument).Msg("transition must connect two different stages").Err()
+		}
+		for _, f := range r.RequiredFields {
+			if !slices.Contains(transitionFields, f) {
+				return nil, errs.B().Code(errs.InvalidArgument).Msg("required_fields may only contain 'assignee_id' and 'description'").Err()
+			}
+		}
+	}
+
+	tx, err := boardDB.Begin(ctx)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to start transaction").Cause(err).Err()
+	}
+	defer tx.Rollback()
+
+	if _, err := lockStages(ctx, tx, boardID); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch stages").Cause(err).Err()
+	}
+
+	_, err = tx.Exec(ctx, `
+        DELETE FROM board_stage_transitions
+        WHERE board_id = $1
+    `, boardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to clear transitions").Cause(err).Err()
+	}
+
+	for _, r := range p.Transitions {
+		requiredFields := r.RequiredFields
+		if requiredFields == nil {
+			requiredFields = []string{}
+		}
+		result, err := tx.Exec(ctx, `
+            INSERT INTO board_stage_transitions (board_id, from_stage_id, to_stage_id, admin_only, required_fields)
+            SELECT $1, f.id, t.id, $4, $5
+            FROM board_stages f, board_stages t
+            WHERE f.board_id = $1 AND f.name = $2
+              AND t.board_id = $1 AND t.name = $3
+            ON CONFLICT DO NOTHING
+        `, boardID, r.From, r.To, r.AdminOnly, requiredFields)
+		if err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to save transition").Cause(err).Err()
+		}
+		if result.RowsAffected() == 0 {
+			return nil, errs.B().Code(errs.InvalidArgument).Msgf("transition '%s' -> '%s' must connect existing stages and be listed once", r.From, r.To).Err()
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to commit transaction").Cause(err).Err()
+	}
+
+	return listTransitions(ctx, boardID)
+}
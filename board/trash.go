@@ -0,0 +1,229 @@
+package board
+
+import (
+	"context"
+	"time"
+
+	"encore.app/authz"
+	"encore.dev/beta/auth"
+	"encore.dev/beta/errs"
+	"encore.dev/config"
+	"encore.dev/cron"
+	"encore.dev/rlog"
+	"encore.dev/storage/sqldb"
+)
+
+// Config holds the board service configuration, loaded from config.cue.
+type Config struct {
+	// TrashRetentionDays is the number of days a deleted board stays in the trash
+	// before it is permanently purged.
+	TrashRetentionDays config.Int
+}
+
+var cfg = config.Load[*Config]()
+
+// The purge-trashed-boards cron job permanently deletes boards whose trash retention
+// period has ended.
+var _ = cron.NewJob("purge-trashed-boards", cron.JobConfig{
+	Title:    "Purge boards past their trash retention period",
+	Every:    1 * cron.Hour,
+	Endpoint: PurgeTrashedBoards,
+})
+
+// TrashedBoardResponse represents a board in the trash.
+type TrashedBoardResponse struct {
+	ID          string `json:"id"`          // board id
+	Name        string `json:"name"`        // board name
+	Description string `json:"description"` // board description
+	CreatedBy   string `json:"created_by"`  // UID of board Owner
+	CreatedAt   string `json:"created_at"`  // Board creation time
+	DeletedBy   string `json:"deleted_by"`  // UID of the Admin who deleted the board
+	DeletedAt   string `json:"deleted_at"`  // time the board was moved to the trash
+	PurgeAt     string `json:"purge_at"`    // time after which the board is permanently deleted
+}
+
+// ListTrashedBoardsResponse represents the trashed boards of the authenticated user.
+type ListTrashedBoardsResponse struct {
+	Boards []TrashedBoardResponse `json:"boards"`
+}
+
+// ListTrashedBoards retrieves the boards in the trash which the authenticated user has the
+// board.delete permission on.
+//
+//encore:api auth method=GET path=/boards/trash
+func ListTrashedBoards(ctx context.Context) (*ListTrashedBoardsResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	rows, err := boardDB.Query(ctx, `
+        SELECT b.id, b.name, b.description, b.created_by, b.created_at, b.deleted_by, b.deleted_at, m.role
+        FROM boards b
+        JOIN board_members m ON m.board_id = b.id
+        WHERE m.user_id = $1 AND b.deleted_at IS NOT NULL
+        ORDER BY b.deleted_at DESC
+    `, uid)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch trashed boards").Cause(err).Err()
+	}
+	defer rows.Close()
+
+	retention := trashRetention()
+	var all []TrashedBoardResponse
+	var roles []string
+	for rows.Next() {
+		var b TrashedBoardResponse
+		var createdAt, deletedAt time.Time
+		var role string
+		if err := rows.Scan(&b.ID, &b.Name, &b.Description, &b.CreatedBy, &createdAt, &b.DeletedBy, &deletedAt, &role); err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to scan board").Cause(err).Err()
+		}
+		b.CreatedAt = createdAt.Format(time.RFC3339)
+		b.DeletedAt = deletedAt.Format(time.RFC3339)
+		b.PurgeAt = deletedAt.Add(retention).Format(time.RFC3339)
+		all = append(all, b)
+		roles = append(roles, role)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("error reading trashed boards").Cause(err).Err()
+	}
+
+	var boards []TrashedBoardResponse
+	for i, b := range all {
+		_, err := authorizeRole(ctx, b.ID, roles[i], authz.BoardDelete)
+		switch {
+		case err == nil:
+			boards = append(boards, b)
+		case errs.Code(err) != errs.PermissionDenied:
+			return nil, err
+		}
+	}
+
+	return &ListTrashedBoardsResponse{Boards: boards}, nil
+}
+
+// RestoreBoard moves a board out of the trash, restricted to users with the board.delete
+// permission and only possible within the trash retention period.
+//
+//encore:api auth method=POST path=/board/:boardID/restore
+func RestoreBoard(ctx context.Context, boardID string) (*BoardResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	tx, err := boardDB.Begin(ctx)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to start transaction").Cause(err).Err()
+	}
+	defer tx.Rollback()
+
+	// authorize ignores trashed boards, so the member's role is looked up here, with the board
+	// locked until it is restored.
+	var role string
+	err = tx.QueryRow(ctx, `
+        SELECT m.role
+        FROM boards b
+        JOIN board_members m ON m.board_id = b.id
+        WHERE b.id = $1 AND m.user_id = $2
+          AND b.deleted_at IS NOT NULL
+          AND b.deleted_at > NOW() - make_interval(days => $3)
+        FOR UPDATE OF b
+    `, boardID, uid, cfg.TrashRetentionDays()).Scan(&role)
+	if err != nil {
+		if err == sqldb.ErrNoRows {
+			return nil, errs.B().Code(errs.NotFound).Msg("board not found in trash or insufficient permissions").Err()
+		}
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch board").Cause(err).Err()
+	}
+	if _, err := authorizeRole(ctx, boardID, role, authz.BoardDelete); err != nil {
+		return nil, err
+	}
+
+	var resp BoardResponse
+	var createdAt time.Time
+	err = tx.QueryRow(ctx, `
+        UPDATE boards
+        SET deleted_at = NULL, deleted_by = NULL
+        WHERE id = $1
+        RETURNING id, name, description, created_by, created_at, archived_at IS NOT NULL
+    `, boardID).Scan(&resp.ID, &resp.Name, &resp.Description, &resp.CreatedBy, &createdAt, &resp.Archived)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to restore board").Cause(err).Err()
+	}
+	resp.CreatedAt = createdAt.Format(time.RFC3339)
+
+	if err := tx.Commit(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to commit transaction").Cause(err).Err()
+	}
+
+	return &resp, nil
+}
+
+// PurgeTrashedBoardsResponse summarizes a single purge run.
+type PurgeTrashedBoardsResponse struct {
+	Purged int `json:"purged"` // number of boards permanently deleted
+}
+
+// PurgeTrashedBoards permanently deletes boards whose trash retention period has ended and
+// emits a BoardDeletedEvent for each of them through the outbox. It is invoked by the purge
+// cron job.
+//
+//encore:api private method=POST path=/internal/boards/purge
+func PurgeTrashedBoards(ctx context.Context) (*PurgeTrashedBoardsResponse, error) {
+	tx, err := boardDB.Begin(ctx)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to start transaction").Cause(err).Err()
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(ctx, `
+        DELETE FROM boards
+        WHERE deleted_at IS NOT NULL
+          AND deleted_at <= NOW() - make_interval(days => $1)
+        RETURNING id
+    `, cfg.TrashRetentionDays())
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to purge boards").Cause(err).Err()
+	}
+
+	var boardIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, errs.B().Code(errs.Internal).Msg("failed to scan board id").Cause(err).Err()
+		}
+		boardIDs = append(boardIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("error reading purged boards").Cause(err).Err()
+	}
+
+	for _, id := range boardIDs {
+		if _, err := enqueueEvent(ctx, tx, "board-deleted", &BoardDeletedEvent{BoardID: id}); err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to record board deletion event").Cause(err).Err()
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to commit transaction").Cause(err).Err()
+	}
+
+	// Publish right away; if this fails the outbox relay retries the events later.
+	if len(boardIDs) > 0 {
+		if _, _, err := relayOutbox(ctx, 0); err != nil {
+			rlog.Warn("failed to publish board deletion events, leaving them to the outbox relay", "boards", len(boardIDs), "err", err)
+		}
+	}
+
+	return &PurgeTrashedBoardsResponse{Purged: len(boardIDs)}, nil
+}
+
+// trashRetention returns the configured trash retention period.
+func trashRetention() time.Duration {
+	return time.Duration(cfg.TrashRetentionDays()) * 24 * time.Hour
+}
@@ -0,0 +1,22 @@
+// priority package defines the task priority levels shared by the board and task services. The
+// board service stores a default priority per board; task service assigns priorities to tasks.
+package priority
+
+import "slices"
+
+// Priority levels.
+const (
+	Urgent = "urgent"
+	High   = "high"
+	Medium = "medium"
+	Low    = "low"
+	None   = "none"
+)
+
+// Levels lists every priority level, from the most to the least urgent.
+var Levels = []string{Urgent, High, Medium, Low, None}
+
+// Valid reports whether p is a priority level.
+func Valid(p string) bool {
+	return slices.Contains(Levels, p)
+}
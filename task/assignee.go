@@ -0,0 +1,231 @@
+package task
+
+import (
+	"context"
+	"time"
+
+	"encore.app/authz"
+	"encore.app/board"
+	"encore.dev/beta/auth"
+	"encore.dev/beta/errs"
+	"encore.dev/pubsub"
+	"encore.dev/storage/sqldb"
+)
+
+// Subscribes to the MemberRemovedTopic to unassign tasks of users removed from a board.
+var _ = pubsub.NewSubscription(
+	board.MemberRemovedTopic, "unassign-tasks-on-member-removal",
+	pubsub.SubscriptionConfig[*board.MemberRemovedEvent]{
+		Handler: handleMemberRemovedEvent,
+	},
+)
+
+// member-removed event handler
+func handleMemberRemovedEvent(ctx context.Context, event *board.MemberRemovedEvent) error {
+	_, err := taskDB.Exec(ctx, `
+		WITH removed AS (
+			DELETE FROM task_assignees a
+			USING tasks t
+			WHERE a.task_id = t.id AND t.board_id = $1 AND a.user_id::text = $2
+			RETURNING a.task_id
+		)
+		UPDATE tasks
+		SET version = version + 1, updated_at = NOW()
+		WHERE id IN (SELECT task_id FROM removed)
+	`, event.BoardID, event.UserID)
+	if err != nil {
+		return errs.B().Code(errs.Internal).Msg("failed to unassign tasks of removed member").Cause(err).Err()
+	}
+	return nil
+}
+
+// AssigneesResponse represents the assignees of a task.
+type AssigneesResponse struct {
+	TaskID      string   `json:"task_id"`      // task id
+	AssigneeIDs []string `json:"assignee_ids"` // user ids of assignees, in assignment order
+}
+
+// AddAssigneeParams defines the user to assign to a task.
+type AddAssigneeParams struct {
+	UserID string `json:"user_id"` // user id of the new assignee
+}
+
+// AddAssignee assigns a board member to a task, restricted to users with the task.update.any
+// permission, or task.update.own for the task creator.
+//
+//encore:api auth method=POST path=/task/:taskID/assignees
+func AddAssignee(ctx context.Context, taskID string, p *AddAssigneeParams) (*AssigneesResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if p.UserID == "" {
+		return nil, errs.B().Code(errs.InvalidArgument).Msg("user_id is required").Err()
+	}
+
+	boardID, err := authorizeTaskChange(ctx, taskID, string(uid))
+	if err != nil {
+		return nil, err
+	}
+	if err := validateAssignees(ctx, boardID, []string{p.UserID}); err != nil {
+		return nil, err
+	}
+
+	tx, err := taskDB.Begin(ctx)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to start transaction").Cause(err).Err()
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(ctx, `
+        INSERT INTO task_assignees (task_id, user_id)
+        VALUES ($1, $2)
+        ON CONFLICT DO NOTHING
+    `, taskID, p.UserID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to assign task").Cause(err).Err()
+	}
+	if result.RowsAffected() == 0 {
+		return nil, errs.B().Code(errs.AlreadyExists).Msg("user is already assigned to this task").Err()
+	}
+
+	return commitAssigneeChange(ctx, tx, taskID)
+}
+
+// RemoveAssignee unassigns a user from a task, restricted to users with the task.update.any
+// permission, or task.update.own for the task creator.
+//
+//encore:api auth method=DELETE path=/task/:taskID/assignees/:userID
+func RemoveAssignee(ctx context.Context, taskID, userID string) (*AssigneesResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if _, err := authorizeTaskChange(ctx, taskID, string(uid)); err != nil {
+		return nil, err
+	}
+
+	tx, err := taskDB.Begin(ctx)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to start transaction").Cause(err).Err()
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(ctx, `
+        DELETE FROM task_assignees
+        WHERE task_id = $1 AND user_id::text = $2
+    `, taskID, userID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to unassign task").Cause(err).Err()
+	}
+	if result.RowsAffected() == 0 {
+		return nil, errs.B().Code(errs.NotFound).Msg("user is not assigned to this task").Err()
+	}
+
+	return commitAssigneeChange(ctx, tx, taskID)
+}
+
+// authorizeTaskChange checks that the authenticated user may change the assignees or labels
+// of a task and returns the task's board id.
+func authorizeTaskChange(ctx context.Context, taskID, uid string) (string, error) {
+	boardID, createdBy, err := taskOwner(ctx, taskID)
+	if err != nil {
+		return "", err
+	}
+
+	membership, err := authorizeTask(ctx, boardID, createdBy, uid, authz.TaskUpdateOwn, authz.TaskUpdateAny)
+	if err != nil {
+		return "", err
+	}
+	if membership.Archived {
+		return "", errs.B().Code(errs.FailedPrecondition).Msg("board is archived and read-only").Err()
+	}
+	return boardID, nil
+}
+
+// commitAssigneeChange bumps the version of a task whose assignees were changed within tx,
+// commits tx, and returns the new assignees.
+func commitAssigneeChange(ctx context.Context, tx *sqldb.Tx, taskID string) (*AssigneesResponse, error) {
+	if err := bumpVersion(ctx, tx, taskID); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to update task version").Cause(err).Err()
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to commit transaction").Cause(err).Err()
+	}
+
+	return taskAssignees(ctx, taskID)
+}
+
+// taskAssignees returns the current assignees of a task.
+func taskAssignees(ctx context.Context, taskID string) (*AssigneesResponse, error) {
+	assignees, err := loadAssignees(ctx, []string{taskID})
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch assignees").Cause(err).Err()
+	}
+	ids := assignees[taskID]
+	if ids == nil {
+		ids = []string{}
+	}
+	return &AssigneesResponse{TaskID: taskID, AssigneeIDs: ids}, nil
+}
+
+// loadAssignees returns the assignees of a set of tasks, keyed by task id and in assignment
+// order. Tasks without assignees are absent from the map.
+func loadAssignees(ctx context.Context, taskIDs []string) (map[string][]string, error) {
+	rows, err := taskDB.Query(ctx, `
+        SELECT task_id, user_id
+        FROM task_assignees
+        WHERE task_id::text = ANY($1::text[])
+        ORDER BY assigned_at, user_id
+    `, taskIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	assignees := make(map[string][]string)
+	for rows.Next() {
+		var taskID, userID string
+		if err := rows.Scan(&taskID, &userID); err != nil {
+			return nil, err
+		}
+		assignees[taskID] = append(assignees[taskID], userID)
+	}
+	return assignees, rows.Err()
+}
+
+// insertAssignees assigns users to a task within tx, skipping existing assignments.
+func insertAssignees(ctx context.Context, tx *sqldb.Tx, taskID string, userIDs []string, assignedAt time.Time) error {
+	_, err := tx.Exec(ctx, `
+        INSERT INTO task_assignees (task_id, user_id, assigned_at)
+        SELECT $1, unnest($2::uuid[]), $3
+        ON CONFLICT DO NOTHING
+    `, taskID, userIDs, assignedAt)
+	return err
+}
+
+// validateAssignees checks that users can be assigned tasks on a board: each must be a member
+// whose role allows working on tasks.
+func validateAssignees(ctx context.Context, boardID string, userIDs []string) error {
+	members, err := board.CheckMembers(ctx, boardID, &board.CheckMembersParams{UserIDs: userIDs})
+	if err != nil {
+		return errs.B().Code(errs.Internal).Msg("failed to check assignee membership").Cause(err).Err()
+	}
+
+	found := make(map[string]bool, len(members.Members))
+	for _, m := range members.Members {
+		if !authz.Has(m.Permissions, authz.TaskUpdateOwn) && !authz.Has(m.Permissions, authz.TaskUpdateAny) {
+			return errs.B().Code(errs.InvalidArgument).Msgf("role '%s' cannot be assigned tasks", m.Role).Err()
+		}
+		found[m.UserID] = true
+	}
+	for _, id := range userIDs {
+		if !found[id] {
+			return errs.B().Code(errs.InvalidArgument).Msgf("assignee '%s' must be a member of the board", id).Err()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package task
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"encore.dev/beta/errs"
+)
+
+// uuidPattern matches the text form of a UUID, as task ids are encoded in cursors.
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// cursor is the position of the last task of a page in a sorted task list. It is handed to
+// clients as an opaque string; paging by position rather than offset keeps pages stable while
+// tasks are added or removed.
+type cursor struct {
+	Sort   string   `json:"s"`  // sort parameter the cursor was created for
+	Values []string `json:"v"`  // sort key of the last task, as text
+	ID     string   `json:"id"` // id of the last task
+}
+
+// encode returns the opaque string form of the cursor.
+func (c *cursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor parses a cursor returned by a previous list call with the same sort order. The
+// values of the cursor are checked against the types of the sort key, so a tampered cursor is
+// reported as invalid rather than failing the query.
+func decodeCursor(s string, order taskOrder) (*cursor, error) {
+	var c cursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err == nil {
+		err = json.Unmarshal(data, &c)
+	}
+	if err != nil || !uuidPattern.MatchString(c.ID) || len(c.Values) != len(order.key.casts) {
+		return nil, errs.B().Code(errs.InvalidArgument).Msg("invalid cursor").Err()
+	}
+	if c.Sort != order.sort {
+		return nil, errs.B().Code(errs.InvalidArgument).Msg("cursor was created for a different sort order").Err()
+	}
+	for i, v := range c.Values {
+		if !validCursorValue(order.key.casts[i], v) {
+			return nil, errs.B().Code(errs.InvalidArgument).Msg("invalid cursor").Err()
+		}
+	}
+	return &c, nil
+}
+
+// validCursorValue reports whether v is the text form of a value of the SQL type cast, as
+// produced by taskOrder.values.
+func validCursorValue(cast, v string) bool {
+	switch cast {
+	case "timestamp":
+		_, err := time.Parse("2006-01-02 15:04:05.999999999", v)
+		return err == nil
+	case "date":
+		_, err := time.Parse(dateLayout, v)
+		return err == nil || v == "infinity"
+	case "int":
+		_, err := strconv.ParseInt(v, 10, 32)
+		return err == nil
+	case "text":
+		return utf8.ValidString(v) && !strings.ContainsRune(v, 0)
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+//go:build encore_app
+
+package task
+
+import (
+	"testing"
+
+	"encore.dev/beta/errs"
+)
+
+func TestDecodeCursor(t *testing.T) {
+	const id = "3f1c2a9e-8b7d-4c6e-9a5f-1e2d3c4b5a69"
+	tests := []struct {
+		sort   string
+		values []string
+		id     string
+		valid  bool
+	}{
+		{sort: "created_at", values: []string{"2024-05-01 12:30:45.123456"}, id: id, valid: true},
+		{sort: "-updated_at", values: []string{"2024-05-01 12:30:45"}, id: id, valid: true},
+		{sort: "created_at", values: []string{"yesterday"}, id: id},
+		{sort: "created_at", values: []string{"2024-05-01 12:30:45"}, id: "1; DROP TABLE tasks"},
+		{sort: "due_date", values: []string{"2024-05-01"}, id: id, valid: true},
+		{sort: "due_date", values: []string{"infinity"}, id: id, valid: true},
+		{sort: "due_date", values: []string{"2024-13-01"}, id: id},
+		{sort: "priority", values: []string{"2"}, id: id, valid: true},
+		{sort: "priority", values: []string{"urgent"}, id: id},
+		{sort: "priority", values: []string{"99999999999"}, id: id},
+		{sort: "title", values: []string{"any title"}, id: id, valid: true},
+		{sort: "title", values: []string{"nul\x00"}, id: id},
+		{sort: "title", values: []string{"a", "b"}, id: id},
+	}
+
+	for _, tt := range tests {
+		order, err := parseSort(tt.sort)
+		if err != nil {
+			t.Fatalf("parseSort(%q): %v", tt.sort, err)
+		}
+		s := (&cursor{Sort: order.sort, Values: tt.values, ID: tt.id}).encode()
+		_, err = decodeCursor(s, order)
+		if tt.valid && err != nil {
+			t.Errorf("%s %q: unexpected error %v", tt.sort, tt.values, err)
+		}
+		if !tt.valid && errs.Code(err) != errs.InvalidArgument {
+			t.Errorf("%s %q %q: got %v, want InvalidArgument", tt.sort, tt.values, tt.id, err)
+		}
+	}
+
+	order, _ := parseSort("title")
+	s := (&cursor{Sort: "-title", Values: []string{"x"}, ID: id}).encode()
+	if _, err := decodeCursor(s, order); errs.Code(err) != errs.InvalidArgument {
+		t.Errorf("cursor of another sort order: got %v, want InvalidArgument", err)
+	}
+}
@@ -0,0 +1,93 @@
+package task
+
+import (
+	"encoding/json"
+	"time"
+
+	"encore.dev/beta/errs"
+)
+
+// dateLayout is the format of task start and due dates. Dates have no time of day or time
+// zone; "today" is determined in the time zone of the client when filtering.
+const dateLayout = "2006-01-02"
+
+// parseDate parses an optional date field, returning nil for an empty value.
+func parseDate(field, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return nil, errs.B().Code(errs.InvalidArgument).Msgf("%s must be a date in YYYY-MM-DD format", field).Err()
+	}
+	return &t, nil
+}
+
+// patchDate applies a merge patch field to an optional date: an omitted field keeps current,
+// null clears the date, and a date string replaces it.
+func patchDate(raw json.RawMessage, field string, current *time.Time) (*time.Time, error) {
+	if len(raw) == 0 {
+		return current, nil
+	}
+	value, err := patchString(raw, field, "", true)
+	if err != nil {
+		return nil, err
+	}
+	return parseDate(field, value)
+}
+
+// formatDate formats an optional date, returning "" for nil.
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(dateLayout)
+}
+
+// validateDateRange checks that a task does not start after it is due.
+func validateDateRange(start, due *time.Time) error {
+	if start != nil && due != nil && start.After(*due) {
+		return errs.B().Code(errs.InvalidArgument).Msg("start_date must not be after due_date").Err()
+	}
+	return nil
+}
+
+// addDueFilter adds a filter for tasks matching a due date filter to f. today is the current
+// date in the client's time zone and doneStages maps board ids to the stage whose tasks count as
+// completed and are never overdue.
+func addDueFilter(f *taskFilter, due string, today time.Time, doneStages map[string]string) error {
+	switch due {
+	case "overdue":
+		boardIDs := make([]string, 0, len(doneStages))
+		stages := make([]string, 0, len(doneStages))
+		for boardID, stage := range doneStages {
+			boardIDs = append(boardIDs, boardID)
+			stages = append(stages, stage)
+		}
+		f.add("due_date < ?::date AND (board_id::text, stage) NOT IN (SELECT * FROM unnest(?::text[], ?::text[]))",
+			today.Format(dateLayout), boardIDs, stages)
+	case "this_week":
+		// Weeks run from Monday to Sunday.
+		start := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+		f.add("due_date BETWEEN ?::date AND ?::date", start.Format(dateLayout), start.AddDate(0, 0, 6).Format(dateLayout))
+	case "none":
+		f.add("due_date IS NULL")
+	default:
+		return errs.B().Code(errs.InvalidArgument).Msg("due must be one of: 'overdue', 'this_week', 'none'").Err()
+	}
+	return nil
+}
+
+// clientToday returns the current date in the named IANA time zone, or in UTC if tz is empty.
+func clientToday(tz string) (time.Time, error) {
+	loc := time.UTC
+	if tz != "" {
+		var err error
+		loc, err = time.LoadLocation(tz)
+		if err != nil {
+			return time.Time{}, errs.B().Code(errs.InvalidArgument).Msg("tz must be an IANA time zone name, e.g. 'Europe/Berlin'").Err()
+		}
+	}
+	y, m, d := time.Now().In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC), nil
+}
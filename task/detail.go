@@ -0,0 +1,146 @@
+package task
+
+import (
+	"context"
+	"time"
+
+	"encore.app/user"
+	"encore.dev/beta/auth"
+	"encore.dev/beta/errs"
+	"encore.dev/storage/sqldb"
+)
+
+// TaskLabel represents a label attached to a task.
+type TaskLabel struct {
+	ID    string `json:"id"`    // label id
+	Name  string `json:"name"`  // label name
+	Color string `json:"color"` // hex color, e.g. "#d73a4a"
+}
+
+// HistorySummary summarizes the recorded changes to a task, see GetTaskHistory.
+type HistorySummary struct {
+	Count        int    `json:"count"`          // number of history entries
+	LastAction   string `json:"last_action"`    // kind of the latest change, e.g. "moved_to_board"
+	LastActionAt string `json:"last_action_at"` // time of the latest change
+}
+
+// TaskDetailResponse represents a single task with its board, users and labels resolved.
+type TaskDetailResponse struct {
+	Task      TaskResponse    `json:"task"`              // the task
+	BoardName string          `json:"board_name"`        // name of the task's board
+	Creator   *user.Profile   `json:"creator,omitempty"` // profile of the task creator, omitted if the user no longer exists
+	Assignees []user.Profile  `json:"assignees"`         // profiles of the assignees
+	Labels    []TaskLabel     `json:"labels"`            // attached labels, ordered by name
+	History   *HistorySummary `json:"history,omitempty"` // summary of the task history, omitted if none was recorded
+	ETag      string          `header:"ETag"`            // ETag of the task version, for If-Match on updates
+}
+
+// GetTask retrieves a single task with its board name, the profiles of its creator and
+// assignees, its labels and a summary of its history, accessible to users with the task.view
+// permission. Tasks the user may not view are reported as not found.
+//
+//encore:api auth method=GET path=/task/:taskID
+func GetTask(ctx context.Context, taskID string) (*TaskDetailResponse, error) {
+	_, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	membership, err := authorizeTaskView(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := loadTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := append([]string{t.CreatedBy}, t.AssigneeIDs...)
+	profiles, err := user.GetProfiles(ctx, &user.GetProfilesParams{UserIDs: userIDs})
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch user profiles").Cause(err).Err()
+	}
+
+	byID := make(map[string]user.Profile, len(profiles.Profiles))
+	for _, p := range profiles.Profiles {
+		byID[p.ID] = p
+	}
+
+	labels, err := taskLabelDetails(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	history, err := taskHistorySummary(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &TaskDetailResponse{
+		Task:      *t,
+		BoardName: membership.BoardName,
+		Assignees: []user.Profile{},
+		Labels:    labels,
+		History:   history,
+		ETag:      taskETag(t.Version),
+	}
+	if p, ok := byID[t.CreatedBy]; ok {
+		resp.Creator = &p
+	}
+	for _, id := range t.AssigneeIDs {
+		if p, ok := byID[id]; ok {
+			resp.Assignees = append(resp.Assignees, p)
+		}
+	}
+
+	return resp, nil
+}
+
+// taskLabelDetails returns the labels attached to a task, ordered by name.
+func taskLabelDetails(ctx context.Context, taskID string) ([]TaskLabel, error) {
+	rows, err := taskDB.Query(ctx, `
+        SELECT l.id, l.name, l.color
+        FROM task_labels tl
+        JOIN labels l ON l.id = tl.label_id
+        WHERE tl.task_id = $1
+        ORDER BY LOWER(l.name)
+    `, taskID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch labels").Cause(err).Err()
+	}
+	defer rows.Close()
+
+	labels := []TaskLabel{}
+	for rows.Next() {
+		var l TaskLabel
+		if err := rows.Scan(&l.ID, &l.Name, &l.Color); err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to scan label").Cause(err).Err()
+		}
+		labels = append(labels, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("error reading labels").Cause(err).Err()
+	}
+	return labels, nil
+}
+
+// taskHistorySummary returns the summary of a task's history, or nil if none was recorded.
+func taskHistorySummary(ctx context.Context, taskID string) (*HistorySummary, error) {
+	var h HistorySummary
+	var lastAt time.Time
+	err := taskDB.QueryRow(ctx, `
+        SELECT COUNT(*) OVER (), action, created_at
+        FROM task_history
+        WHERE task_id = $1
+        ORDER BY id DESC
+        LIMIT 1
+    `, taskID).Scan(&h.Count, &h.LastAction, &lastAt)
+	if err != nil {
+		if err == sqldb.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch task history").Cause(err).Err()
+	}
+	h.LastActionAt = lastAt.Format(time.RFC3339)
+	return &h, nil
+}
@@ -0,0 +1,46 @@
+//go:build encore_app
+
+package task
+
+import (
+	"testing"
+
+	"encore.app/board"
+)
+
+func TestGetTaskResolvesLabelsAndHistory(t *testing.T) {
+	boardID, admin := newBoard(t)
+	labels, err := CreateLabel(as(admin), boardID, &CreateLabelParams{Name: "bug", Color: "#d73a4a"})
+	if err != nil {
+		t.Fatalf("CreateLabel: %v", err)
+	}
+	task := newTask(t, boardID, admin, &CreateTaskParams{LabelIDs: []string{labels.Labels[0].ID}})
+
+	detail, err := GetTask(as(admin), task.ID)
+	if err != nil {
+		t.Fatalf("GetTask: %v", err)
+	}
+	want := TaskLabel{ID: labels.Labels[0].ID, Name: "bug", Color: "#d73a4a"}
+	if len(detail.Labels) != 1 || detail.Labels[0] != want {
+		t.Errorf("labels = %+v, want [%+v]", detail.Labels, want)
+	}
+	if detail.History != nil {
+		t.Errorf("history = %+v, want none", detail.History)
+	}
+
+	target, err := board.CreateBoard(as(admin), &board.CreateBoardParams{Name: t.Name() + " target"})
+	if err != nil {
+		t.Fatalf("CreateBoard: %v", err)
+	}
+	copied, err := CopyTaskToBoard(as(admin), task.ID, &TransferTaskParams{BoardID: target.ID})
+	if err != nil {
+		t.Fatalf("CopyTaskToBoard: %v", err)
+	}
+	detail, err = GetTask(as(admin), copied.ID)
+	if err != nil {
+		t.Fatalf("GetTask: %v", err)
+	}
+	if detail.History == nil || detail.History.Count != 1 || detail.History.LastAction != actionCopiedFromTask {
+		t.Errorf("history = %+v, want one %s entry", detail.History, actionCopiedFromTask)
+	}
+}
@@ -0,0 +1,216 @@
+package task
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"encore.dev/beta/errs"
+)
+
+// taskFilter builds the WHERE clause of a task query. Conditions are written with ?
+// placeholders, which are numbered in the order the conditions and their arguments are added.
+type taskFilter struct {
+	conds []string
+	args  []any
+}
+
+// add appends a condition with one ? placeholder per argument.
+func (f *taskFilter) add(cond string, args ...any) {
+	for _, arg := range args {
+		cond = strings.Replace(cond, "?", f.arg(arg), 1)
+	}
+	f.conds = append(f.conds, cond)
+}
+
+// arg appends an argument and returns its placeholder.
+func (f *taskFilter) arg(v any) string {
+	f.args = append(f.args, v)
+	return fmt.Sprintf("$%d", len(f.args))
+}
+
+// where returns the conditions joined with AND.
+func (f *taskFilter) where() string {
+	if len(f.conds) == 0 {
+		return "TRUE"
+	}
+	return strings.Join(f.conds, " AND ")
+}
+
+// clone returns a copy of the filter that can be extended without changing f.
+func (f *taskFilter) clone() *taskFilter {
+	return &taskFilter{conds: slices.Clone(f.conds), args: slices.Clone(f.args)}
+}
+
+// sortKey is a key tasks can be sorted by.
+type sortKey struct {
+	exprs []string // SQL expressions of the key, compared in order; never NULL so tasks can be paged by them
+	casts []string // SQL types the expressions are cast back to from their cursor values
+}
+
+// stageIDsArg stands for the argument holding the stage ids of the listed boards in sort
+// expressions; taskOrder.bind replaces it by a placeholder.
+const stageIDsArg = "{stage_ids}"
+
+// sortKeys are the keys tasks can be sorted by. Tasks without due date sort as if due last.
+// Sorting by rank groups tasks by stage in the board's column order and keeps the manual order
+// within each stage.
+var sortKeys = map[string]sortKey{
+	"created_at": {exprs: []string{"created_at"}, casts: []string{"timestamp"}},
+	"updated_at": {exprs: []string{"updated_at"}, casts: []string{"timestamp"}},
+	"due_date":   {exprs: []string{"COALESCE(due_date, 'infinity'::date)"}, casts: []string{"date"}},
+	"priority":   {exprs: []string{priorityRank}, casts: []string{"int"}},
+	"title":      {exprs: []string{"LOWER(title)"}, casts: []string{"text"}},
+	"rank":       {exprs: []string{"COALESCE(array_position(" + stageIDsArg + "::uuid[], stage_id), 0)", "rank"}, casts: []string{"int", "text"}},
+}
+
+// taskOrder is the parsed sort parameter of a task list.
+type taskOrder struct {
+	sort string  // normalized sort parameter, e.g. "-due_date"
+	key  sortKey // sort key
+	desc bool    // true for descending order
+}
+
+// parseSort parses a sort parameter: a sort key name, optionally prefixed with "-" for
+// descending order. Tasks are sorted by created_at if sort is empty.
+func parseSort(sort string) (taskOrder, error) {
+	name := strings.TrimPrefix(sort, "-")
+	if name == "" {
+		name = "created_at"
+	}
+	key, ok := sortKeys[name]
+	if !ok {
+		return taskOrder{}, errs.B().Code(errs.InvalidArgument).
+			Msg("sort must be one of: 'created_at', 'updated_at', 'due_date', 'priority', 'title', 'rank', optionally prefixed with '-'").Err()
+	}
+	desc := strings.HasPrefix(sort, "-")
+	if desc {
+		name = "-" + name
+	}
+	return taskOrder{sort: name, key: key, desc: desc}, nil
+}
+
+// bind adds the arguments the sort expressions refer to to f and returns the order with the
+// expressions referring to them. stageIDs are the stage ids of the listed boards, each board's
+// in column order. Orders must be bound before they are used in a query.
+func (o taskOrder) bind(f *taskFilter, stageIDs []string) taskOrder {
+	exprs := slices.Clone(o.key.exprs)
+	placeholder := ""
+	for i, expr := range exprs {
+		if !strings.Contains(expr, stageIDsArg) {
+			continue
+		}
+		if placeholder == "" {
+			placeholder = f.arg(stageIDs)
+		}
+		exprs[i] = strings.ReplaceAll(expr, stageIDsArg, placeholder)
+	}
+	o.key.exprs = exprs
+	return o
+}
+
+// orderBy returns the ORDER BY clause of the order, using the task id to break ties.
+func (o taskOrder) orderBy() string {
+	dir := "ASC"
+	if o.desc {
+		dir = "DESC"
+	}
+	terms := make([]string, 0, len(o.key.exprs)+1)
+	for _, expr := range o.key.exprs {
+		terms = append(terms, expr+" "+dir)
+	}
+	return strings.Join(append(terms, "id "+dir), ", ")
+}
+
+// values returns an SQL expression for the sort key of a task as a text array, from which
+// cursors are created.
+func (o taskOrder) values() string {
+	values := make([]string, len(o.key.exprs))
+	for i, expr := range o.key.exprs {
+		values[i] = fmt.Sprintf("(%s)::text", expr)
+	}
+	return "ARRAY[" + strings.Join(values, ", ") + "]"
+}
+
+// after adds the condition selecting the tasks following the cursor position to f. It reports
+// an InvalidArgument error if the cursor does not fit the sort key.
+func (o taskOrder) after(f *taskFilter, c *cursor) error {
+	if len(c.Values) != len(o.key.exprs) {
+		return errs.B().Code(errs.InvalidArgument).Msg("invalid cursor").Err()
+	}
+	op := ">"
+	if o.desc {
+		op = "<"
+	}
+	placeholders := make([]string, 0, len(c.Values)+1)
+	args := make([]any, 0, len(c.Values)+1)
+	for i, v := range c.Values {
+		placeholders = append(placeholders, "?::"+o.key.casts[i])
+		args = append(args, v)
+	}
+	placeholders = append(placeholders, "?::uuid")
+	args = append(args, c.ID)
+	f.add(fmt.Sprintf("(%s, id) %s (%s)", strings.Join(o.key.exprs, ", "), op, strings.Join(placeholders, ", ")), args...)
+	return nil
+}
+
+// addTextFilter adds a filter for tasks whose title or description contains text, ignoring
+// case.
+func addTextFilter(f *taskFilter, text string) {
+	pattern := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(text) + "%"
+	f.add("(title ILIKE ? OR description ILIKE ?)", pattern, pattern)
+}
+
+// addLabelFilter adds a filter for tasks carrying any or all of the labels, depending on match.
+func addLabelFilter(f *taskFilter, labelIDs []string, match string) error {
+	labelIDs = slices.Compact(slices.Sorted(slices.Values(labelIDs)))
+	switch match {
+	case "", "any":
+		f.add("EXISTS (SELECT 1 FROM task_labels l WHERE l.task_id = tasks.id AND l.label_id::text = ANY(?::text[]))", labelIDs)
+	case "all":
+		f.add("(SELECT COUNT(*) FROM task_labels l WHERE l.task_id = tasks.id AND l.label_id::text = ANY(?::text[])) = ?", labelIDs, len(labelIDs))
+	default:
+		return errs.B().Code(errs.InvalidArgument).Msg("label_match must be 'any' or 'all'").Err()
+	}
+	return nil
+}
+
+// addTimeRange adds a filter for tasks whose timestamp column lies in [from, to). Both bounds
+// are optional RFC3339 times; param names the parameters in errors.
+func addTimeRange(f *taskFilter, column, param, from, to string) error {
+	for _, bound := range []struct{ param, value, op string }{
+		{param + "_from", from, ">="},
+		{param + "_to", to, "<"},
+	} {
+		if bound.value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, bound.value)
+		if err != nil {
+			return errs.B().Code(errs.InvalidArgument).Msgf("%s must be an RFC3339 time", bound.param).Err()
+		}
+		f.add(fmt.Sprintf("%s %s ?", column, bound.op), t)
+	}
+	return nil
+}
+
+// addDateRange adds a filter for tasks whose date column lies in [from, to]. Both bounds are
+// optional dates in YYYY-MM-DD format; param names the parameters in errors.
+func addDateRange(f *taskFilter, column, param, from, to string) error {
+	fromDate, err := parseDate(param+"_from", from)
+	if err != nil {
+		return err
+	}
+	toDate, err := parseDate(param+"_to", to)
+	if err != nil {
+		return err
+	}
+	if fromDate != nil {
+		f.add(column+" >= ?::date", fromDate.Format(dateLayout))
+	}
+	if toDate != nil {
+		f.add(column+" <= ?::date", toDate.Format(dateLayout))
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"encore.dev/beta/auth"
+	"encore.dev/beta/errs"
+	"encore.dev/storage/sqldb"
+)
+
+// Task history actions.
+const (
+	actionMovedToBoard   = "moved_to_board"   // details: from_board_id, from_stage, to_board_id, to_stage
+	actionCopiedFromTask = "copied_from_task" // details: source_task_id, source_board_id
+)
+
+// HistoryEntry represents a recorded change to a task.
+type HistoryEntry struct {
+	ID        int64             `json:"id"`         // entry id, increasing over time
+	ActorID   string            `json:"actor_id"`   // user who made the change
+	Action    string            `json:"action"`     // kind of change, e.g. "moved_to_board"
+	Details   map[string]string `json:"details"`    // action specific details
+	CreatedAt string            `json:"created_at"` // time of the change
+}
+
+// TaskHistoryResponse represents the history of a task, oldest entry first.
+type TaskHistoryResponse struct {
+	Entries []HistoryEntry `json:"entries"`
+}
+
+// GetTaskHistory retrieves the recorded changes to a task, accessible to users with the
+// task.view permission on the task's current board. Tasks the user may not view are reported
+// as not found.
+//
+//encore:api auth method=GET path=/task/:taskID/history
+func GetTaskHistory(ctx context.Context, taskID string) (*TaskHistoryResponse, error) {
+	_, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if _, err := authorizeTaskView(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	rows, err := taskDB.Query(ctx, `
+        SELECT id, actor_id, action, details, created_at
+        FROM task_history
+        WHERE task_id = $1
+        ORDER BY id
+    `, taskID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch task history").Cause(err).Err()
+	}
+	defer rows.Close()
+
+	entries := []HistoryEntry{}
+	for rows.Next() {
+		var e HistoryEntry
+		var details []byte
+		var createdAt time.Time
+		if err := rows.Scan(&e.ID, &e.ActorID, &e.Action, &details, &createdAt); err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to scan task history").Cause(err).Err()
+		}
+		if err := json.Unmarshal(details, &e.Details); err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to decode task history").Cause(err).Err()
+		}
+		e.CreatedAt = createdAt.Format(time.RFC3339)
+		entries = append(entries, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("error reading task history").Cause(err).Err()
+	}
+
+	return &TaskHistoryResponse{Entries: entries}, nil
+}
+
+// recordHistory records a change to a task within tx.
+func recordHistory(ctx context.Context, tx *sqldb.Tx, taskID, actorID, action string, details map[string]string) error {
+	data, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+        INSERT INTO task_history (task_id, actor_id, action, details)
+        VALUES ($1, $2, $3, $4::jsonb)
+    `, taskID, actorID, action, string(data))
+	return err
+}
@@ -0,0 +1,484 @@
+package task
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"encore.app/authz"
+	"encore.dev/beta/auth"
+	"encore.dev/beta/errs"
+	"encore.dev/storage/sqldb"
+)
+
+// maxLabelNameLength is the maximum length of a label name.
+const maxLabelNameLength = 50
+
+// labelColorPattern matches the hex colors labels can have, e.g. "#d73a4a".
+var labelColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// LabelResponse represents a label defined on a board.
+type LabelResponse struct {
+	ID        string `json:"id"`         // label id
+	BoardID   string `json:"board_id"`   // board the label belongs to
+	Name      string `json:"name"`       // label name, unique per board ignoring case
+	Color     string `json:"color"`      // hex color, e.g. "#d73a4a"
+	TaskCount int    `json:"task_count"` // number of tasks the label is attached to
+}
+
+// ListLabelsResponse represents the labels of a board, ordered by name.
+type ListLabelsResponse struct {
+	Labels []LabelResponse `json:"labels"`
+}
+
+// ListLabels retrieves the labels of a board with their usage counts, accessible to users with
+// the task.view permission.
+//
+//encore:api auth method=GET path=/board/:boardID/labels
+func ListLabels(ctx context.Context, boardID string) (*ListLabelsResponse, error) {
+	_, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if _, err := authorize(ctx, boardID, authz.TaskView); err != nil {
+		return nil, err
+	}
+
+	return listLabels(ctx, boardID)
+}
+
+// CreateLabelParams defines the input parameters for creating a label.
+type CreateLabelParams struct {
+	Name  string `json:"name"`  // label name
+	Color string `json:"color"` // hex color, e.g. "#d73a4a"
+}
+
+// CreateLabel defines a new label on a board, restricted to users with the board.manage
+// permission.
+//
+//encore:api auth method=POST path=/board/:boardID/labels
+func CreateLabel(ctx context.Context, boardID string, p *CreateLabelParams) (*ListLabelsResponse, error) {
+	_, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if _, err := authorize(ctx, boardID, authz.BoardManage); err != nil {
+		return nil, err
+	}
+
+	name, err := validateLabelName(p.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateLabelColor(p.Color); err != nil {
+		return nil, err
+	}
+
+	_, err = taskDB.Exec(ctx, `
+        INSERT INTO labels (board_id, name, color)
+        VALUES ($1, $2, $3)
+    `, boardID, name, strings.ToLower(p.Color))
+	if err != nil {
+		if sqldb.ErrCode(err) == "23505" { // PostgreSQL unique violation
+			return nil, errs.B().Code(errs.AlreadyExists).Msg("label already exists").Err()
+		}
+		return nil, errs.B().Code(errs.Internal).Msg("failed to create label").Cause(err).Err()
+	}
+
+	return listLabels(ctx, boardID)
+}
+
+// UpdateLabelParams defines the changes to a label; empty fields are kept.
+type UpdateLabelParams struct {
+	Name  string `json:"name,omitempty"`  // new label name
+	Color string `json:"color,omitempty"` // new hex color
+}
+
+// UpdateLabel renames or recolors a label, restricted to users with the board.manage
+// permission. The change applies to every task the label is attached to.
+//
+//encore:api auth method=PATCH path=/board/:boardID/labels/:labelID
+func UpdateLabel(ctx context.Context, boardID, labelID string, p *UpdateLabelParams) (*ListLabelsResponse, error) {
+	_, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if _, err := authorize(ctx, boardID, authz.BoardManage); err != nil {
+		return nil, err
+	}
+
+	if p.Name == "" && p.Color == "" {
+		return nil, errs.B().Code(errs.InvalidArgument).Msg("name or color is required").Err()
+	}
+	name := ""
+	if p.Name != "" {
+		var err error
+		if name, err = validateLabelName(p.Name); err != nil {
+			return nil, err
+		}
+	}
+	if p.Color != "" {
+		if err := validateLabelColor(p.Color); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := taskDB.Exec(ctx, `
+        UPDATE labels
+        SET name = COALESCE(NULLIF($1, ''), name), color = COALESCE(NULLIF($2, ''), color)
+        WHERE id::text = $3 AND board_id = $4
+    `, name, strings.ToLower(p.Color), labelID, boardID)
+	if err != nil {
+		if sqldb.ErrCode(err) == "23505" { // PostgreSQL unique violation
+			return nil, errs.B().Code(errs.AlreadyExists).Msg("label already exists").Err()
+		}
+		return nil, errs.B().Code(errs.Internal).Msg("failed to update label").Cause(err).Err()
+	}
+	if result.RowsAffected() == 0 {
+		return nil, errs.B().Code(errs.NotFound).Msg("label not found").Err()
+	}
+
+	return listLabels(ctx, boardID)
+}
+
+// DeleteLabel deletes a label and detaches it from all tasks, restricted to users with the
+// board.manage permission.
+//
+//encore:api auth method=DELETE path=/board/:boardID/labels/:labelID
+func DeleteLabel(ctx context.Context, boardID, labelID string) (*ListLabelsResponse, error) {
+	_, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if _, err := authorize(ctx, boardID, authz.BoardManage); err != nil {
+		return nil, err
+	}
+
+	tx, err := taskDB.Begin(ctx)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to start transaction").Cause(err).Err()
+	}
+	defer tx.Rollback()
+
+	if err := bumpLabeledVersions(ctx, tx, labelID); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to update task versions").Cause(err).Err()
+	}
+
+	// Task labels are removed by the foreign key cascade.
+	result, err := tx.Exec(ctx, `
+        DELETE FROM labels
+        WHERE id::text = $1 AND board_id = $2
+    `, labelID, boardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to delete label").Cause(err).Err()
+	}
+	if result.RowsAffected() == 0 {
+		return nil, errs.B().Code(errs.NotFound).Msg("label not found").Err()
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to commit transaction").Cause(err).Err()
+	}
+
+	return listLabels(ctx, boardID)
+}
+
+// MergeLabelParams defines the label another label is merged into.
+type MergeLabelParams struct {
+	Into string `json:"into"` // id of the label that replaces the merged label on its tasks
+}
+
+// MergeLabel merges a label into another label of the same board, restricted to users with
+// the board.manage permission. Tasks carrying the merged label get the target label instead,
+// and the merged label is deleted.
+//
+//encore:api auth method=POST path=/board/:boardID/labels/:labelID/merge
+func MergeLabel(ctx context.Context, boardID, labelID string, p *MergeLabelParams) (*ListLabelsResponse, error) {
+	_, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if _, err := authorize(ctx, boardID, authz.BoardManage); err != nil {
+		return nil, err
+	}
+
+	if p.Into == "" {
+		return nil, errs.B().Code(errs.InvalidArgument).Msg("into is required").Err()
+	}
+	if p.Into == labelID {
+		return nil, errs.B().Code(errs.InvalidArgument).Msg("cannot merge a label into itself").Err()
+	}
+	if err := validateLabels(ctx, boardID, []string{p.Into}); err != nil {
+		return nil, err
+	}
+
+	tx, err := taskDB.Begin(ctx)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to start transaction").Cause(err).Err()
+	}
+	defer tx.Rollback()
+
+	if err := bumpLabeledVersions(ctx, tx, labelID); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to update task versions").Cause(err).Err()
+	}
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO task_labels (task_id, label_id)
+        SELECT task_id, $2 FROM task_labels
+        WHERE label_id::text = $1
+        ON CONFLICT DO NOTHING
+    `, labelID, p.Into)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to relabel tasks").Cause(err).Err()
+	}
+
+	result, err := tx.Exec(ctx, `
+        DELETE FROM labels
+        WHERE id::text = $1 AND board_id = $2
+    `, labelID, boardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to delete label").Cause(err).Err()
+	}
+	if result.RowsAffected() == 0 {
+		return nil, errs.B().Code(errs.NotFound).Msg("label not found").Err()
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to commit transaction").Cause(err).Err()
+	}
+
+	return listLabels(ctx, boardID)
+}
+
+// TaskLabelsResponse represents the labels attached to a task.
+type TaskLabelsResponse struct {
+	TaskID   string   `json:"task_id"`   // task id
+	LabelIDs []string `json:"label_ids"` // ids of the attached labels, ordered by name
+}
+
+// AddTaskLabelParams defines the label to attach to a task.
+type AddTaskLabelParams struct {
+	LabelID string `json:"label_id"` // id of a label of the task's board
+}
+
+// AddTaskLabel attaches a label to a task, restricted to users with the task.update.any
+// permission, or task.update.own for the task creator.
+//
+//encore:api auth method=POST path=/task/:taskID/labels
+func AddTaskLabel(ctx context.Context, taskID string, p *AddTaskLabelParams) (*TaskLabelsResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if p.LabelID == "" {
+		return nil, errs.B().Code(errs.InvalidArgument).Msg("label_id is required").Err()
+	}
+
+	boardID, err := authorizeTaskChange(ctx, taskID, string(uid))
+	if err != nil {
+		return nil, err
+	}
+	if err := validateLabels(ctx, boardID, []string{p.LabelID}); err != nil {
+		return nil, err
+	}
+
+	tx, err := taskDB.Begin(ctx)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to start transaction").Cause(err).Err()
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(ctx, `
+        INSERT INTO task_labels (task_id, label_id)
+        VALUES ($1, $2)
+        ON CONFLICT DO NOTHING
+    `, taskID, p.LabelID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to attach label").Cause(err).Err()
+	}
+	if result.RowsAffected() == 0 {
+		return nil, errs.B().Code(errs.AlreadyExists).Msg("label is already attached to this task").Err()
+	}
+
+	return commitLabelChange(ctx, tx, taskID)
+}
+
+// RemoveTaskLabel detaches a label from a task, restricted to users with the task.update.any
+// permission, or task.update.own for the task creator.
+//
+//encore:api auth method=DELETE path=/task/:taskID/labels/:labelID
+func RemoveTaskLabel(ctx context.Context, taskID, labelID string) (*TaskLabelsResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if _, err := authorizeTaskChange(ctx, taskID, string(uid)); err != nil {
+		return nil, err
+	}
+
+	tx, err := taskDB.Begin(ctx)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to start transaction").Cause(err).Err()
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(ctx, `
+        DELETE FROM task_labels
+        WHERE task_id = $1 AND label_id::text = $2
+    `, taskID, labelID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to detach label").Cause(err).Err()
+	}
+	if result.RowsAffected() == 0 {
+		return nil, errs.B().Code(errs.NotFound).Msg("label is not attached to this task").Err()
+	}
+
+	return commitLabelChange(ctx, tx, taskID)
+}
+
+// commitLabelChange bumps the version of a task whose labels were changed within tx, commits
+// tx, and returns the new labels.
+func commitLabelChange(ctx context.Context, tx *sqldb.Tx, taskID string) (*TaskLabelsResponse, error) {
+	if err := bumpVersion(ctx, tx, taskID); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to update task version").Cause(err).Err()
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to commit transaction").Cause(err).Err()
+	}
+
+	return taskLabels(ctx, taskID)
+}
+
+// taskLabels returns the labels currently attached to a task.
+func taskLabels(ctx context.Context, taskID string) (*TaskLabelsResponse, error) {
+	labels, err := loadLabels(ctx, []string{taskID})
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch labels").Cause(err).Err()
+	}
+	ids := labels[taskID]
+	if ids == nil {
+		ids = []string{}
+	}
+	return &TaskLabelsResponse{TaskID: taskID, LabelIDs: ids}, nil
+}
+
+// loadLabels returns the label ids of a set of tasks, keyed by task id and ordered by label
+// name. Tasks without labels are absent from the map.
+func loadLabels(ctx context.Context, taskIDs []string) (map[string][]string, error) {
+	rows, err := taskDB.Query(ctx, `
+        SELECT tl.task_id, tl.label_id
+        FROM task_labels tl
+        JOIN labels l ON l.id = tl.label_id
+        WHERE tl.task_id::text = ANY($1::text[])
+        ORDER BY LOWER(l.name)
+    `, taskIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	labels := make(map[string][]string)
+	for rows.Next() {
+		var taskID, labelID string
+		if err := rows.Scan(&taskID, &labelID); err != nil {
+			return nil, err
+		}
+		labels[taskID] = append(labels[taskID], labelID)
+	}
+	return labels, rows.Err()
+}
+
+// insertLabels attaches labels to a task within tx, skipping labels already attached.
+func insertLabels(ctx context.Context, tx *sqldb.Tx, taskID string, labelIDs []string) error {
+	_, err := tx.Exec(ctx, `
+        INSERT INTO task_labels (task_id, label_id)
+        SELECT $1, unnest($2::uuid[])
+        ON CONFLICT DO NOTHING
+    `, taskID, labelIDs)
+	return err
+}
+
+// validateLabels checks that labels are defined on a board. labelIDs must not contain
+// duplicates.
+func validateLabels(ctx context.Context, boardID string, labelIDs []string) error {
+	var count int
+	err := taskDB.QueryRow(ctx, `
+        SELECT COUNT(*)
+        FROM labels
+        WHERE board_id = $1 AND id::text = ANY($2::text[])
+    `, boardID, labelIDs).Scan(&count)
+	if err != nil {
+		return errs.B().Code(errs.Internal).Msg("failed to check labels").Cause(err).Err()
+	}
+	if count != len(labelIDs) {
+		return errs.B().Code(errs.InvalidArgument).Msg("labels must be defined on the task's board").Err()
+	}
+	return nil
+}
+
+// bumpLabeledVersions increments the version of all tasks carrying a label within tx.
+func bumpLabeledVersions(ctx context.Context, tx *sqldb.Tx, labelID string) error {
+	_, err := tx.Exec(ctx, `
+        UPDATE tasks
+        SET version = version + 1, updated_at = NOW()
+        WHERE id IN (SELECT task_id FROM task_labels WHERE label_id::text = $1)
+    `, labelID)
+	return err
+}
+
+// listLabels returns the labels of a board with their usage counts.
+func listLabels(ctx context.Context, boardID string) (*ListLabelsResponse, error) {
+	rows, err := taskDB.Query(ctx, `
+        SELECT l.id, l.board_id, l.name, l.color, COUNT(tl.task_id)
+        FROM labels l
+        LEFT JOIN task_labels tl ON tl.label_id = l.id
+        WHERE l.board_id = $1
+        GROUP BY l.id
+        ORDER BY LOWER(l.name)
+    `, boardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch labels").Cause(err).Err()
+	}
+	defer rows.Close()
+
+	labels := []LabelResponse{}
+	for rows.Next() {
+		var l LabelResponse
+		if err := rows.Scan(&l.ID, &l.BoardID, &l.Name, &l.Color, &l.TaskCount); err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to scan label").Cause(err).Err()
+		}
+		labels = append(labels, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("error reading labels").Cause(err).Err()
+	}
+	return &ListLabelsResponse{Labels: labels}, nil
+}
+
+// validateLabelName trims a label name and checks that it is non-empty and not too long.
+func validateLabelName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errs.B().Code(errs.InvalidArgument).Msg("label name is required").Err()
+	}
+	if len(name) > maxLabelNameLength {
+		return "", errs.B().Code(errs.InvalidArgument).Msgf("label name must be at most %d characters", maxLabelNameLength).Err()
+	}
+	return name, nil
+}
+
+// validateLabelColor checks that color is a hex color.
+func validateLabelColor(color string) error {
+	if !labelColorPattern.MatchString(color) {
+		return errs.B().Code(errs.InvalidArgument).Msg("color must be a hex color, e.g. '#d73a4a'").Err()
+	}
+	return nil
+}
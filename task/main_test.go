@@ -0,0 +1,73 @@
+//go:build encore_app
+
+// The task service needs the Encore runtime, its test databases and the board service, so its
+// tests only build under `encore test`.
+
+package task
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"encore.app/board"
+	"encore.dev/beta/auth"
+)
+
+// newUserID returns a random user id.
+func newUserID(t *testing.T) auth.UID {
+	t.Helper()
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatal(err)
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return auth.UID(fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]))
+}
+
+// as returns a context authenticated as uid.
+func as(uid auth.UID) context.Context {
+	return auth.WithContext(context.Background(), uid, nil)
+}
+
+// newBoard creates a board administered by a new user and returns its id and the Admin.
+func newBoard(t *testing.T) (string, auth.UID) {
+	t.Helper()
+	admin := newUserID(t)
+	b, err := board.CreateBoard(as(admin), &board.CreateBoardParams{Name: t.Name()})
+	if err != nil {
+		t.Fatalf("CreateBoard: %v", err)
+	}
+	return b.ID, admin
+}
+
+// addMember invites a new user to a board with role and accepts the invitation on their behalf.
+func addMember(t *testing.T, boardID string, admin auth.UID, role string) auth.UID {
+	t.Helper()
+	uid := newUserID(t)
+	inv, err := board.InviteUser(as(admin), &board.InviteUserParams{BoardID: boardID, InviteeID: string(uid), Role: role})
+	if err != nil {
+		t.Fatalf("InviteUser: %v", err)
+	}
+	_, err = board.HandleInvitation(as(uid), &board.HandleInvitationParams{InvitationID: inv.InvitationID, Action: "Accepted"})
+	if err != nil {
+		t.Fatalf("HandleInvitation: %v", err)
+	}
+	return uid
+}
+
+// newTask creates a task on a board as uid.
+func newTask(t *testing.T, boardID string, uid auth.UID, p *CreateTaskParams) *TaskResponse {
+	t.Helper()
+	p.BoardID = boardID
+	if p.Title == "" {
+		p.Title = t.Name()
+	}
+	task, err := CreateTask(as(uid), p)
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+	return task
+}
@@ -0,0 +1,193 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"encore.app/authz"
+	"encore.dev/beta/auth"
+	"encore.dev/beta/errs"
+)
+
+// maxMyTasksLimit is the maximum number of tasks returned by ListMyTasks at once.
+const maxMyTasksLimit = 500
+
+// MyTasksParams defines the query parameters for listing the authenticated user's tasks.
+type MyTasksParams struct {
+	Relation        string   `query:"relation,omitempty"`  // "assigned" (tasks assigned to the user), "created" (tasks they created) or "all" (default, either)
+	Stages          []string `query:"stage,omitempty"`     // Filter by stage names; repeat the parameter for several stages
+	DueFrom         string   `query:"due_from,omitempty"`  // Only tasks due on or after this date, YYYY-MM-DD
+	DueTo           string   `query:"due_to,omitempty"`    // Only tasks due on or before this date, YYYY-MM-DD
+	Due             string   `query:"due,omitempty"`       // Filter by due date: "overdue", "this_week" or "none"
+	TZ              string   `query:"tz,omitempty"`        // IANA time zone that determines today for the due filter, defaults to UTC
+	IncludeArchived bool     `query:"include_archived"`    // include tasks of archived boards (default false)
+	Sort            string   `query:"sort,omitempty"`      // sort key as for ListTasks; defaults to "due_date"
+	Limit           int      `query:"limit" default:"100"` // Number of tasks to return across all boards, at most 500
+	Cursor          string   `query:"cursor,omitempty"`    // next_cursor of the previous page; must be used with the same sort
+}
+
+// BoardTasks represents the tasks of one board.
+type BoardTasks struct {
+	BoardID   string         `json:"board_id"`   // board id
+	BoardName string         `json:"board_name"` // name of the board
+	Tasks     []TaskResponse `json:"tasks"`      // tasks of the board
+}
+
+// MyTasksResponse represents a page of the authenticated user's tasks grouped by board, ordered
+// by board name.
+type MyTasksResponse struct {
+	Boards     []BoardTasks `json:"boards"`                // boards with at least one matching task on this page
+	Total      int          `json:"total"`                 // Total number of matching tasks
+	NextCursor string       `json:"next_cursor,omitempty"` // cursor of the next page, empty on the last page
+}
+
+// ListMyTasks retrieves a page of the tasks assigned to or created by the authenticated user
+// across all boards where they have the task.view permission, grouped by board. Pages are
+// addressed by cursor as for ListTasks; a board's tasks may span several pages.
+//
+//encore:api auth method=GET path=/me/tasks
+func ListMyTasks(ctx context.Context, p *MyTasksParams) (*MyTasksResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if p.Limit <= 0 || p.Limit > maxMyTasksLimit {
+		return nil, errs.B().Code(errs.InvalidArgument).Msgf("limit must be between 1 and %d", maxMyTasksLimit).Err()
+	}
+	sort := p.Sort
+	if sort == "" {
+		sort = "due_date"
+	}
+	order, err := parseSort(sort)
+	if err != nil {
+		return nil, err
+	}
+
+	boards, err := memberBoards(ctx, authz.TaskView)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tasks are grouped in board order; doneStages maps each board to its last stage, whose
+	// tasks count as completed.
+	resp := &MyTasksResponse{Boards: []BoardTasks{}}
+	groups := make(map[string]int)
+	var boardIDs, stageIDs []string
+	doneStages := make(map[string]string)
+	for _, b := range boards {
+		if b.Archived && !p.IncludeArchived {
+			continue
+		}
+		groups[b.BoardID] = len(resp.Boards)
+		resp.Boards = append(resp.Boards, BoardTasks{BoardID: b.BoardID, BoardName: b.BoardName})
+		boardIDs = append(boardIDs, b.BoardID)
+		stageIDs = append(stageIDs, b.StageIDs...)
+		if len(b.Stages) > 0 {
+			doneStages[b.BoardID] = b.Stages[len(b.Stages)-1]
+		}
+	}
+	if len(boardIDs) == 0 {
+		return resp, nil
+	}
+
+	f := &taskFilter{}
+	f.add("board_id::text = ANY(?::text[])", boardIDs)
+	switch p.Relation {
+	case "", "all":
+		f.add("(created_by = ? OR EXISTS (SELECT 1 FROM task_assignees a WHERE a.task_id = tasks.id AND a.user_id = ?))", string(uid), string(uid))
+	case "assigned":
+		f.add("EXISTS (SELECT 1 FROM task_assignees a WHERE a.task_id = tasks.id AND a.user_id = ?)", string(uid))
+	case "created":
+		f.add("created_by = ?", string(uid))
+	default:
+		return nil, errs.B().Code(errs.InvalidArgument).Msg("relation must be 'assigned', 'created' or 'all'").Err()
+	}
+	if len(p.Stages) > 0 {
+		f.add("stage = ANY(?::text[])", p.Stages)
+	}
+	if err := addDateRange(f, "due_date", "due", p.DueFrom, p.DueTo); err != nil {
+		return nil, err
+	}
+	if p.Due != "" {
+		today, err := clientToday(p.TZ)
+		if err != nil {
+			return nil, err
+		}
+		if err := addDueFilter(f, p.Due, today, doneStages); err != nil {
+			return nil, err
+		}
+	}
+
+	err = taskDB.QueryRow(ctx, "SELECT COUNT(*) FROM tasks WHERE "+f.where(), f.args...).Scan(&resp.Total)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to count tasks").Cause(err).Err()
+	}
+
+	order = order.bind(f, stageIDs)
+	if p.Cursor != "" {
+		c, err := decodeCursor(p.Cursor, order)
+		if err != nil {
+			return nil, err
+		}
+		if err := order.after(f, c); err != nil {
+			return nil, err
+		}
+	}
+	// Fetch one task more than requested to learn whether there is a next page.
+	limit := f.arg(p.Limit + 1)
+	rows, err := taskDB.Query(ctx, fmt.Sprintf(`
+        SELECT id, board_id, title, COALESCE(description, ''), created_by, stage, rank, start_date, due_date, priority, version, created_at, updated_at, %s
+        FROM tasks
+        WHERE %s
+        ORDER BY %s
+        LIMIT %s
+    `, order.values(), f.where(), order.orderBy(), limit), f.args...)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch tasks").Cause(err).Err()
+	}
+	defer rows.Close()
+
+	var tasks []TaskResponse
+	var sortValues [][]string
+	for rows.Next() {
+		var t TaskResponse
+		var startDate, dueDate *time.Time
+		var createdAt, updatedAt time.Time
+		var sortValue []string
+		if err := rows.Scan(&t.ID, &t.BoardID, &t.Title, &t.Description, &t.CreatedBy, &t.Stage, &t.Rank, &startDate, &dueDate, &t.Priority, &t.Version, &createdAt, &updatedAt, &sortValue); err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to scan task").Cause(err).Err()
+		}
+		t.StartDate = formatDate(startDate)
+		t.DueDate = formatDate(dueDate)
+		t.CreatedAt = createdAt.Format(time.RFC3339)
+		t.UpdatedAt = updatedAt.Format(time.RFC3339)
+		tasks = append(tasks, t)
+		sortValues = append(sortValues, sortValue)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("error reading tasks").Cause(err).Err()
+	}
+
+	if len(tasks) > p.Limit {
+		tasks = tasks[:p.Limit]
+		last := p.Limit - 1
+		resp.NextCursor = (&cursor{Sort: order.sort, Values: sortValues[last], ID: tasks[last].ID}).encode()
+	}
+
+	if err := attachRelations(ctx, tasks); err != nil {
+		return nil, err
+	}
+
+	for _, t := range tasks {
+		g := &resp.Boards[groups[t.BoardID]]
+		g.Tasks = append(g.Tasks, t)
+	}
+	// Only boards with matching tasks are returned.
+	resp.Boards = slices.DeleteFunc(resp.Boards, func(b BoardTasks) bool { return len(b.Tasks) == 0 })
+
+	return resp, nil
+}
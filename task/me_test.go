@@ -0,0 +1,47 @@
+//go:build encore_app
+
+package task
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestListMyTasksPages(t *testing.T) {
+	boardID, admin := newBoard(t)
+	want := make(map[string]bool)
+	for i := 0; i < 5; i++ {
+		task := newTask(t, boardID, admin, &CreateTaskParams{Title: fmt.Sprintf("Task %d", i)})
+		want[task.ID] = true
+	}
+
+	seen := make(map[string]bool)
+	p := &MyTasksParams{Sort: "title", Limit: 2}
+	for pages := 0; ; pages++ {
+		if pages == len(want) {
+			t.Fatal("paging does not terminate")
+		}
+		resp, err := ListMyTasks(as(admin), p)
+		if err != nil {
+			t.Fatalf("ListMyTasks: %v", err)
+		}
+		if resp.Total != len(want) {
+			t.Errorf("total = %d, want %d", resp.Total, len(want))
+		}
+		for _, b := range resp.Boards {
+			for _, task := range b.Tasks {
+				if seen[task.ID] {
+					t.Errorf("task %s returned twice", task.ID)
+				}
+				seen[task.ID] = true
+			}
+		}
+		if resp.NextCursor == "" {
+			break
+		}
+		p.Cursor = resp.NextCursor
+	}
+	if len(seen) != len(want) {
+		t.Errorf("got %d tasks across pages, want %d", len(seen), len(want))
+	}
+}
@@ -0,0 +1,16 @@
+-- Manual order of tasks within a stage. Ranks are strings compared byte-wise, so a task can be
+-- placed between two others by giving it a rank between theirs without renumbering the stage.
+ALTER TABLE tasks ADD COLUMN rank VARCHAR(255) COLLATE "C";
+
+-- Existing tasks keep their creation order. Generated ranks never end in '0'.
+UPDATE tasks t
+SET rank = r.rank
+FROM (
+    SELECT id, LPAD(TO_HEX(ROW_NUMBER() OVER (PARTITION BY board_id, stage ORDER BY created_at, id)), 8, '0') || 'i' AS rank
+    FROM tasks
+) r
+WHERE t.id = r.id;
+
+ALTER TABLE tasks ALTER COLUMN rank SET NOT NULL;
+
+CREATE INDEX tasks_board_id_stage_rank_idx ON tasks (board_id, stage, rank);
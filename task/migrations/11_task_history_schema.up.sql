@@ -0,0 +1,11 @@
+-- History of changes to tasks
+CREATE TABLE task_history (
+    id BIGSERIAL PRIMARY KEY,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    actor_id UUID NOT NULL,  -- User ID (from User Service) of the user making the change
+    action VARCHAR(30) NOT NULL,  -- e.g. 'moved_to_board'
+    details JSONB NOT NULL DEFAULT '{}',  -- action specific details
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX task_history_task_id_idx ON task_history (task_id, id);
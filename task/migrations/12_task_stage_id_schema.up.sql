@@ -0,0 +1,15 @@
+-- Stage a task belongs to, by id. Tasks follow their stage when it is renamed, and the tasks of a
+-- deleted stage are moved by id, so a stage name never refers to the wrong stage. The stage
+-- column keeps the stage name for filtering and display. Existing tasks get their stage id from
+-- the board's stages the first time the board is synced.
+ALTER TABLE tasks ADD COLUMN stage_id UUID;
+
+CREATE INDEX tasks_board_id_stage_id_idx ON tasks (board_id, stage_id);
+
+-- Board Stage Syncs Table
+-- Stage layout version of each board that the stages recorded on its tasks reflect.
+CREATE TABLE board_stage_syncs (
+    board_id UUID PRIMARY KEY,  -- Reference to Board (from Board Service)
+    version INT NOT NULL,  -- stage layout version the tasks were last synced to
+    synced_at TIMESTAMP DEFAULT NOW()
+);
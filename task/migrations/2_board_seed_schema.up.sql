@@ -0,0 +1,7 @@
+-- Board Seeds Table
+-- Records boards that were already populated from a BoardSeedEvent, so redelivered
+-- events do not create duplicate tasks.
+CREATE TABLE board_seeds (
+    board_id UUID PRIMARY KEY,  -- Reference to Board (from Board Service)
+    seeded_at TIMESTAMP DEFAULT NOW()
+);
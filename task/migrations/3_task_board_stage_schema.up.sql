@@ -0,0 +1,6 @@
+-- Stages are defined per board by the board service and validated by the task service.
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_stage_check;
+ALTER TABLE tasks ALTER COLUMN stage TYPE VARCHAR(50);
+ALTER TABLE tasks ALTER COLUMN stage SET NOT NULL;
+
+CREATE INDEX tasks_board_id_stage_idx ON tasks (board_id, stage);
@@ -0,0 +1,17 @@
+-- Task Assignees Table
+-- A task can be assigned to several users; replaces tasks.assignee_id.
+CREATE TABLE task_assignees (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,  -- User ID (from User Service)
+    assigned_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (task_id, user_id)
+);
+
+CREATE INDEX task_assignees_user_id_idx ON task_assignees (user_id);
+
+INSERT INTO task_assignees (task_id, user_id, assigned_at)
+SELECT id, assignee_id, updated_at
+FROM tasks
+WHERE assignee_id IS NOT NULL;
+
+ALTER TABLE tasks DROP COLUMN assignee_id;
@@ -0,0 +1,2 @@
+-- Version of a task, incremented on every change for optimistic concurrency control.
+ALTER TABLE tasks ADD COLUMN version INT NOT NULL DEFAULT 1;
@@ -0,0 +1,5 @@
+-- Date-only start and due dates of tasks; NULL means not set.
+ALTER TABLE tasks ADD COLUMN start_date DATE;
+ALTER TABLE tasks ADD COLUMN due_date DATE;
+
+CREATE INDEX tasks_board_id_due_date_idx ON tasks (board_id, due_date);
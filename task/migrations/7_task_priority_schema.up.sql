@@ -0,0 +1,6 @@
+-- Task priority
+ALTER TABLE tasks
+    ADD COLUMN priority VARCHAR(10) NOT NULL DEFAULT 'none'
+        CHECK (priority IN ('urgent', 'high', 'medium', 'low', 'none'));
+
+CREATE INDEX tasks_board_id_priority_idx ON tasks (board_id, priority);
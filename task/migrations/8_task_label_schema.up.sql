@@ -0,0 +1,19 @@
+-- Labels defined per board and attached to tasks
+CREATE TABLE labels (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    board_id UUID NOT NULL,  -- Reference to Board (from Board Service)
+    name VARCHAR(50) NOT NULL,
+    color CHAR(7) NOT NULL,  -- hex color, e.g. '#d73a4a'
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Label names are unique per board, ignoring case
+CREATE UNIQUE INDEX labels_board_id_name_idx ON labels (board_id, LOWER(name));
+
+CREATE TABLE task_labels (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    label_id UUID NOT NULL REFERENCES labels(id) ON DELETE CASCADE,
+    PRIMARY KEY (task_id, label_id)
+);
+
+CREATE INDEX task_labels_label_id_idx ON task_labels (label_id);
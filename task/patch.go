@@ -0,0 +1,58 @@
+package task
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+
+	"encore.dev/beta/errs"
+)
+
+// patchString applies a merge patch field to a string value: an omitted field keeps current,
+// null clears the value if the field is nullable, and a string replaces it.
+func patchString(raw json.RawMessage, field, current string, nullable bool) (string, error) {
+	if len(raw) == 0 {
+		return current, nil
+	}
+	if isNull(raw) {
+		if !nullable {
+			return "", errs.B().Code(errs.InvalidArgument).Msgf("%s cannot be null", field).Err()
+		}
+		return "", nil
+	}
+
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "", errs.B().Code(errs.InvalidArgument).Msgf("%s must be a string", field).Err()
+	}
+	return value, nil
+}
+
+// patchStrings applies a merge patch field to a list of strings: an omitted field keeps
+// current, null clears the list, and an array replaces it. Duplicates are removed. It reports
+// whether the field was present.
+func patchStrings(raw json.RawMessage, field string, current []string) ([]string, bool, error) {
+	if len(raw) == 0 {
+		return current, false, nil
+	}
+	if isNull(raw) {
+		return []string{}, true, nil
+	}
+
+	var values []string
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil, false, errs.B().Code(errs.InvalidArgument).Msgf("%s must be an array of strings", field).Err()
+	}
+	result := []string{}
+	for _, v := range values {
+		if !slices.Contains(result, v) {
+			result = append(result, v)
+		}
+	}
+	return result, true, nil
+}
+
+// isNull reports whether a raw JSON value is null.
+func isNull(raw json.RawMessage) bool {
+	return bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
+}
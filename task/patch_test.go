@@ -0,0 +1,49 @@
+//go:build encore_app
+
+package task
+
+import (
+	"encoding/json"
+	"testing"
+
+	"encore.dev/beta/auth"
+)
+
+// updateJSON calls UpdateTask with a JSON request body, decoded as the API decodes it.
+func updateJSON(t *testing.T, task *TaskResponse, body string) *TaskResponse {
+	t.Helper()
+	var p UpdateTaskParams
+	if err := json.Unmarshal([]byte(body), &p); err != nil {
+		t.Fatalf("decode %s: %v", body, err)
+	}
+	p.Version = task.Version
+	updated, err := UpdateTask(as(auth.UID(task.CreatedBy)), task.ID, &p)
+	if err != nil {
+		t.Fatalf("UpdateTask %s: %v", body, err)
+	}
+	return updated
+}
+
+func TestUpdateTaskMergePatchNull(t *testing.T) {
+	boardID, admin := newBoard(t)
+	task := newTask(t, boardID, admin, &CreateTaskParams{
+		Description: "details",
+		AssigneeIDs: []string{string(admin)},
+	})
+
+	task = updateJSON(t, task, `{"description": null}`)
+	if task.Description != "" {
+		t.Errorf("description = %q, want it cleared", task.Description)
+	}
+	if len(task.AssigneeIDs) != 1 {
+		t.Errorf("assignees = %v, want them kept", task.AssigneeIDs)
+	}
+
+	task = updateJSON(t, task, `{"assignee_ids": null}`)
+	if len(task.AssigneeIDs) != 0 {
+		t.Errorf("assignees = %v, want them cleared", task.AssigneeIDs)
+	}
+	if task.Title != t.Name() {
+		t.Errorf("title = %q, want it kept", task.Title)
+	}
+}
@@ -0,0 +1,88 @@
+package task
+
+import (
+	"context"
+
+	"encore.app/authz"
+	"encore.app/board"
+	"encore.dev/beta/errs"
+	"encore.dev/storage/sqldb"
+)
+
+// authorize fetches the authenticated user's membership of a board and checks that their role
+// grants at least one of perms. It returns the membership, or a PermissionDenied error. The
+// stages of the board's tasks are synced with the board before it returns.
+func authorize(ctx context.Context, boardID string, perms ...string) (*board.CheckMembershipResponse, error) {
+	membership, err := board.CheckMembership(ctx, boardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to check membership").Cause(err).Err()
+	}
+	if !membership.IsMember {
+		return nil, errs.B().Code(errs.PermissionDenied).Msg("access denied: must be a board member").Err()
+	}
+
+	if err := authz.Require(membership.Permissions, perms...); err != nil {
+		return nil, err
+	}
+
+	if err := syncStages(ctx, boardID, membership.StagesVersion); err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+// authorizeTask checks that the authenticated user may modify a task created by createdBy:
+// their role must grant anyPerm, or ownPerm if they created the task.
+func authorizeTask(ctx context.Context, boardID, createdBy, uid, ownPerm, anyPerm string) (*board.CheckMembershipResponse, error) {
+	if createdBy == uid {
+		return authorize(ctx, boardID, ownPerm, anyPerm)
+	}
+	return authorize(ctx, boardID, anyPerm)
+}
+
+// memberBoards returns the boards the authenticated user is a member of whose role grants perm,
+// with the stages of their tasks synced.
+func memberBoards(ctx context.Context, perm string) ([]board.MemberBoard, error) {
+	resp, err := board.ListMemberBoards(ctx, &board.MemberBoardsParams{Permission: perm})
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch boards").Cause(err).Err()
+	}
+	if err := syncBoardStages(ctx, resp.Boards); err != nil {
+		return nil, err
+	}
+	return resp.Boards, nil
+}
+
+// taskOwner returns the board id and creator of a task, which decide who may access it.
+func taskOwner(ctx context.Context, taskID string) (boardID, createdBy string, err error) {
+	err = taskDB.QueryRow(ctx, `
+        SELECT board_id, created_by
+        FROM tasks
+        WHERE id = $1
+    `, taskID).Scan(&boardID, &createdBy)
+	if err != nil {
+		if err == sqldb.ErrNoRows {
+			return "", "", errs.B().Code(errs.NotFound).Msg("task not found").Err()
+		}
+		return "", "", errs.B().Code(errs.Internal).Msg("failed to fetch task").Cause(err).Err()
+	}
+	return boardID, createdBy, nil
+}
+
+// authorizeTaskView checks that the authenticated user may view a task and returns the
+// membership of its board. Tasks the user may not view are reported as not found, exactly like
+// tasks that do not exist, so task ids cannot be probed.
+func authorizeTaskView(ctx context.Context, taskID string) (*board.CheckMembershipResponse, error) {
+	boardID, _, err := taskOwner(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	membership, err := authorize(ctx, boardID, authz.TaskView)
+	if err != nil {
+		if errs.Code(err) == errs.PermissionDenied {
+			return nil, errs.B().Code(errs.NotFound).Msg("task not found").Err()
+		}
+		return nil, err
+	}
+	return membership, nil
+}
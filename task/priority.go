@@ -0,0 +1,29 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+
+	"encore.app/priority"
+	"encore.dev/beta/errs"
+)
+
+// priorityRank is an SQL expression ranking tasks by priority, from 0 for the most urgent
+// level to len(priority.Levels)-1 for none.
+var priorityRank = func() string {
+	var b strings.Builder
+	b.WriteString("CASE priority")
+	for i, level := range priority.Levels {
+		fmt.Fprintf(&b, " WHEN '%s' THEN %d", level, i)
+	}
+	fmt.Fprintf(&b, " ELSE %d END", len(priority.Levels))
+	return b.String()
+}()
+
+// validatePriority checks that p is a priority level.
+func validatePriority(p string) error {
+	if !priority.Valid(p) {
+		return errs.B().Code(errs.InvalidArgument).Msgf("priority must be one of: '%s'", strings.Join(priority.Levels, "', '")).Err()
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package task
+
+import (
+	"context"
+	"time"
+
+	"encore.app/board"
+	"encore.dev/beta/errs"
+)
+
+// GetPublicBoardParams carries the optional password of a public board share.
+type GetPublicBoardParams struct {
+	Password string `header:"X-Share-Password"` // required if the share is password protected
+}
+
+// PublicTask represents a task on a publicly shared board, with user identities redacted.
+type PublicTask struct {
+	ID          string `json:"id"`                    // task id
+	Title       string `json:"title"`                 // task title
+	Description string `json:"description,omitempty"` // task description
+	Stage       string `json:"stage"`                 // task stage
+	Assigned    bool   `json:"assigned"`              // true if the task has an assignee
+	CreatedAt   string `json:"created_at"`            // time of task creation
+	UpdatedAt   string `json:"updated_at"`            // time of last updation
+}
+
+// PublicBoardResponse represents a publicly shared board and its tasks.
+type PublicBoardResponse struct {
+	Board board.SharedBoardResponse `json:"board"`
+	Tasks []PublicTask              `json:"tasks"`
+}
+
+// GetPublicBoard retrieves a board published with a share link and its tasks. It requires no
+// authentication; creators and assignees of tasks are not disclosed. After too many incorrect
+// passwords the share rejects further incorrect ones for a while with ResourceExhausted; the
+// correct password is still accepted.
+//
+//encore:api public method=GET path=/public/board/:shareToken
+func GetPublicBoard(ctx context.Context, shareToken string, p *GetPublicBoardParams) (*PublicBoardResponse, error) {
+	shared, err := board.ResolveShare(ctx, &board.ResolveShareParams{Token: shareToken, Password: p.Password})
+	if err != nil {
+		switch errs.Code(err) {
+		case errs.NotFound, errs.Unauthenticated, errs.PermissionDenied, errs.ResourceExhausted:
+			return nil, err
+		}
+		return nil, errs.B().Code(errs.Internal).Msg("failed to resolve share").Cause(err).Err()
+	}
+	if err := syncStages(ctx, shared.ID, shared.StagesVersion); err != nil {
+		return nil, err
+	}
+
+	rows, err := taskDB.Query(ctx, `
+        SELECT id, title, description,
+               EXISTS (SELECT 1 FROM task_assignees a WHERE a.task_id = tasks.id),
+               stage, created_at, updated_at
+        FROM tasks
+        WHERE board_id = $1
+        ORDER BY array_position($2::text[], stage::text), rank
+    `, shared.ID, shared.Stages)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch tasks").Cause(err).Err()
+	}
+	defer rows.Close()
+
+	tasks := []PublicTask{}
+	for rows.Next() {
+		var t PublicTask
+		var createdAt, updatedAt time.Time
+		if err := rows.Scan(&t.ID, &t.Title, &t.Description, &t.Assigned, &t.Stage, &createdAt, &updatedAt); err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to scan task").Cause(err).Err()
+		}
+		t.CreatedAt = createdAt.Format(time.RFC3339)
+		t.UpdatedAt = updatedAt.Format(time.RFC3339)
+		tasks = append(tasks, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("error reading tasks").Cause(err).Err()
+	}
+
+	return &PublicBoardResponse{Board: *shared, Tasks: tasks}, nil
+}
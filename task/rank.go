@@ -0,0 +1,358 @@
+package task
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	"encore.app/authz"
+	"encore.dev/beta/auth"
+	"encore.dev/beta/errs"
+	"encore.dev/storage/sqldb"
+)
+
+// rankDigits are the digits of task ranks in ascending order. Ranks are compared byte-wise, so
+// the digits must be in ASCII order.
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// Ranks start with an integer part of rankIntegerDigits base-36 digits. Tasks appended to a
+// stage get the next integer, so appending never makes ranks longer; placing a task between
+// two others lengthens the rank, and a stage is rebalanced once a rank would exceed
+// maxRankLength, well within the 255 characters of the rank column.
+const (
+	rankIntegerDigits = 8
+	maxRankLength     = 64
+)
+
+// integerRank returns the rank with integer part n. Like all generated ranks, it does not end
+// in the lowest digit.
+func integerRank(n int64) string {
+	s := strconv.FormatInt(n, len(rankDigits))
+	return strings.Repeat("0", rankIntegerDigits-len(s)) + s + rankBetween("", "")
+}
+
+// rankAfter returns a rank that sorts after last, the highest rank of a stage, or the first
+// rank of an empty stage if last is empty.
+func rankAfter(last string) string {
+	n, err := strconv.ParseInt(rankPadded(last), len(rankDigits), 64)
+	if err != nil || len(strconv.FormatInt(n+1, len(rankDigits))) > rankIntegerDigits {
+		// The integer part is exhausted; fall back to bisecting towards the end.
+		return rankBetween(last, "")
+	}
+	return integerRank(n + 1)
+}
+
+// rankPadded returns the integer part of rank, padded with the lowest digit.
+func rankPadded(rank string) string {
+	if len(rank) >= rankIntegerDigits {
+		return rank[:rankIntegerDigits]
+	}
+	return rank + strings.Repeat(rankDigits[:1], rankIntegerDigits-len(rank))
+}
+
+// rankBetween returns a rank that sorts strictly between a and b, where a must sort before b.
+// An empty a stands for the start of a stage and an empty b for its end. Generated ranks never
+// end in the lowest digit, so there is always room for another rank before them.
+func rankBetween(a, b string) string {
+	if b != "" {
+		// Keep the prefix a and b have in common, padding a with the lowest digit.
+		n := 0
+		for n < len(b) && rankDigit(a, n) == strings.IndexByte(rankDigits, b[n]) {
+			n++
+		}
+		if n > 0 {
+			return b[:n] + rankBetween(rankSuffix(a, n), b[n:])
+		}
+	}
+
+	da := rankDigit(a, 0)
+	db := len(rankDigits)
+	if b != "" {
+		db = strings.IndexByte(rankDigits, b[0])
+	}
+	if db-da > 1 {
+		return string(rankDigits[(da+db)/2])
+	}
+	// The first digits are adjacent: the first digit of b alone sorts between a and a longer b,
+	// otherwise the rank continues after the first digit of a.
+	if len(b) > 1 {
+		return b[:1]
+	}
+	return string(rankDigits[da]) + rankBetween(rankSuffix(a, 1), "")
+}
+
+// rankDigit returns the value of the i-th digit of rank, or 0 past its end.
+func rankDigit(rank string, i int) int {
+	if i < len(rank) {
+		return strings.IndexByte(rankDigits, rank[i])
+	}
+	return 0
+}
+
+// rankSuffix returns rank without its first n digits.
+func rankSuffix(rank string, n int) string {
+	if n < len(rank) {
+		return rank[n:]
+	}
+	return ""
+}
+
+// lockStage locks a stage of a board until tx ends, serializing changes to the tasks in it.
+func lockStage(ctx context.Context, tx *sqldb.Tx, boardID, stage string) error {
+	_, err := tx.Exec(ctx, `
+        SELECT pg_advisory_xact_lock(hashtext($1 || '/' || $2))
+    `, boardID, stage)
+	return err
+}
+
+// rebalanceStage gives the tasks of a stage evenly spaced integer ranks, keeping their order.
+// The stage must be locked within tx. Ranks only order tasks, so rebalancing does not change
+// task versions.
+func rebalanceStage(ctx context.Context, tx *sqldb.Tx, boardID, stage string) error {
+	rows, err := tx.Query(ctx, `
+        SELECT id
+        FROM tasks
+        WHERE board_id = $1 AND stage = $2
+        ORDER BY rank, id
+    `, boardID, stage)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var ids, ranks []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return err
+		}
+		ids = append(ids, id)
+		ranks = append(ranks, integerRank(int64(len(ids))))
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	_, err = tx.Exec(ctx, `
+        UPDATE tasks t
+        SET rank = r.rank
+        FROM unnest($1::uuid[], $2::text[]) AS r(id, rank)
+        WHERE t.id = r.id
+    `, ids, ranks)
+	return err
+}
+
+// nextRank locks a stage within tx and returns the rank that places a task at the end of it.
+func nextRank(ctx context.Context, tx *sqldb.Tx, boardID, stage string) (string, error) {
+	if err := lockStage(ctx, tx, boardID, stage); err != nil {
+		return "", err
+	}
+	var last string
+	err := tx.QueryRow(ctx, `
+        SELECT COALESCE(MAX(rank), '')
+        FROM tasks
+        WHERE board_id = $1 AND stage = $2
+    `, boardID, stage).Scan(&last)
+	if err != nil {
+		return "", err
+	}
+	return rankAfter(last), nil
+}
+
+// MoveTaskParams defines where a task is moved to. If neither after_id nor before_id is given,
+// the task is moved to the end of the stage.
+type MoveTaskParams struct {
+	Stage    string `json:"stage,omitempty"`     // stage to move the task to, defaults to its current stage
+	AfterID  string `json:"after_id,omitempty"`  // task of the stage to place the moved task directly after
+	BeforeID string `json:"before_id,omitempty"` // task of the stage to place the moved task directly before
+	Version  int    `json:"version,omitempty"`   // version the move is based on, if If-Match is not set
+	IfMatch  string `header:"If-Match"`          // ETag of the version the move is based on
+}
+
+// MoveTask moves a task to a position within a stage, restricted to users with the
+// task.update.any permission, or task.update.own for the task creator. Moving a task to
+// another stage is subject to the board's transition rules and WIP limits. The move must name
+// the task version it is based on; if the task was changed since, an Aborted error carrying the
+// current task is returned. Only the moved task is changed, unless the ranks of the stage have
+// grown long and are rebalanced.
+//
+//encore:api auth method=POST path=/task/:taskID/move
+func MoveTask(ctx context.Context, taskID string, p *MoveTaskParams) (*TaskResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if p.AfterID == taskID || p.BeforeID == taskID {
+		return nil, errs.B().Code(errs.InvalidArgument).Msg("a task cannot be moved next to itself").Err()
+	}
+	expected, err := expectedVersion(p.IfMatch, p.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	boardID, createdBy, err := taskOwner(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	membership, err := authorizeTask(ctx, boardID, createdBy, string(uid), authz.TaskUpdateOwn, authz.TaskUpdateAny)
+	if err != nil {
+		return nil, err
+	}
+	if membership.Archived {
+		return nil, errs.B().Code(errs.FailedPrecondition).Msg("board is archived and read-only").Err()
+	}
+
+	t, err := loadTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if t.Version != expected {
+		return nil, conflictError(ctx, taskID)
+	}
+
+	stage := p.Stage
+	if stage == "" {
+		stage = t.Stage
+	}
+	if !slices.Contains(membership.Stages, stage) {
+		return nil, invalidStageError(membership.Stages)
+	}
+	if stage != t.Stage {
+		if err := checkTransition(ctx, t.BoardID, membership.Permissions, t.Stage, stage, t.AssigneeIDs, t.Description); err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := taskDB.Begin(ctx)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to start transaction").Cause(err).Err()
+	}
+	defer tx.Rollback()
+
+	var warnings []string
+	if stage != t.Stage {
+		warning, err := checkWIPLimit(ctx, tx, t.BoardID, stage)
+		if err != nil {
+			return nil, err
+		}
+		if warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+
+	if err := lockStage(ctx, tx, t.BoardID, stage); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to lock stage").Cause(err).Err()
+	}
+	rank, err := moveRank(ctx, tx, t.BoardID, stage, taskID, p.AfterID, p.BeforeID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := tx.Exec(ctx, `
+        UPDATE tasks
+        SET stage = $1, stage_id = $2, rank = $3, version = version + 1, updated_at = NOW()
+        WHERE id = $4 AND version = $5
+    `, stage, stageID(membership, stage), rank, taskID, expected)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to move task").Cause(err).Err()
+	}
+	if result.RowsAffected() == 0 {
+		return nil, conflictError(ctx, taskID)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to commit transaction").Cause(err).Err()
+	}
+
+	resp, err := loadTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	resp.Warnings = warnings
+	return resp, nil
+}
+
+// moveRank returns the rank that places a task between its new neighbours in a stage. The
+// stage must be locked within tx. afterID and beforeID are optional; a missing neighbour is
+// taken to be the task next to the given one, or the end of the stage if neither is given. If
+// the rank would be too long, the stage is rebalanced first.
+func moveRank(ctx context.Context, tx *sqldb.Tx, boardID, stage, taskID, afterID, beforeID string) (string, error) {
+	rank, err := neighbourRanks(ctx, tx, boardID, stage, taskID, afterID, beforeID)
+	if err != nil || len(rank) <= maxRankLength {
+		return rank, err
+	}
+	if err := rebalanceStage(ctx, tx, boardID, stage); err != nil {
+		return "", errs.B().Code(errs.Internal).Msg("failed to rebalance stage").Cause(err).Err()
+	}
+	return neighbourRanks(ctx, tx, boardID, stage, taskID, afterID, beforeID)
+}
+
+// neighbourRanks returns the rank between the new neighbours of a task, see moveRank.
+func neighbourRanks(ctx context.Context, tx *sqldb.Tx, boardID, stage, taskID, afterID, beforeID string) (string, error) {
+	var lower, upper string
+	var err error
+	if afterID != "" {
+		if lower, err = neighbourRank(ctx, tx, boardID, stage, afterID, "after_id"); err != nil {
+			return "", err
+		}
+	}
+	if beforeID != "" {
+		if upper, err = neighbourRank(ctx, tx, boardID, stage, beforeID, "before_id"); err != nil {
+			return "", err
+		}
+	}
+
+	switch {
+	case afterID != "" && beforeID != "":
+		if lower >= upper {
+			return "", errs.B().Code(errs.InvalidArgument).Msg("after_id must be ordered before before_id").Err()
+		}
+	case afterID != "":
+		err = tx.QueryRow(ctx, `
+            SELECT COALESCE(MIN(rank), '')
+            FROM tasks
+            WHERE board_id = $1 AND stage = $2 AND rank > $3 AND id <> $4
+        `, boardID, stage, lower, taskID).Scan(&upper)
+	case beforeID != "":
+		err = tx.QueryRow(ctx, `
+            SELECT COALESCE(MAX(rank), '')
+            FROM tasks
+            WHERE board_id = $1 AND stage = $2 AND rank < $3 AND id <> $4
+        `, boardID, stage, upper, taskID).Scan(&lower)
+	default:
+		err = tx.QueryRow(ctx, `
+            SELECT COALESCE(MAX(rank), '')
+            FROM tasks
+            WHERE board_id = $1 AND stage = $2 AND id <> $3
+        `, boardID, stage, taskID).Scan(&lower)
+	}
+	if err != nil {
+		return "", errs.B().Code(errs.Internal).Msg("failed to fetch task ranks").Cause(err).Err()
+	}
+	if upper == "" {
+		return rankAfter(lower), nil
+	}
+	return rankBetween(lower, upper), nil
+}
+
+// neighbourRank returns the rank of a task the moved task is placed next to. param names the
+// parameter the task was given in, for errors.
+func neighbourRank(ctx context.Context, tx *sqldb.Tx, boardID, stage, taskID, param string) (string, error) {
+	var rank string
+	err := tx.QueryRow(ctx, `
+        SELECT rank
+        FROM tasks
+        WHERE id::text = $1 AND board_id = $2 AND stage = $3
+    `, taskID, boardID, stage).Scan(&rank)
+	if err != nil {
+		if err == sqldb.ErrNoRows {
+			return "", errs.B().Code(errs.InvalidArgument).Msgf("%s must be a task in stage '%s'", param, stage).Err()
+		}
+		return "", errs.B().Code(errs.Internal).Msg("failed to fetch task").Cause(err).Err()
+	}
+	return rank, nil
+}
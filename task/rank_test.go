@@ -0,0 +1,152 @@
+//go:build encore_app
+
+package task
+
+import (
+	"context"
+	"testing"
+
+	"encore.dev/beta/errs"
+)
+
+func TestRankAfterLongSequence(t *testing.T) {
+	last := ""
+	for i := 0; i < 100000; i++ {
+		rank := rankAfter(last)
+		if rank <= last {
+			t.Fatalf("append %d: rank %q does not sort after %q", i, rank, last)
+		}
+		if len(rank) != rankIntegerDigits+1 {
+			t.Fatalf("append %d: rank %q has grown", i, rank)
+		}
+		last = rank
+	}
+
+	// Appending after a long rank placed between two others returns to a short rank.
+	between := integerRank(7)
+	for i := 0; i < 20; i++ {
+		between = rankBetween(between, integerRank(8))
+	}
+	if rank := rankAfter(between); rank <= between || len(rank) != rankIntegerDigits+1 {
+		t.Errorf("rankAfter(%q) = %q, want a short rank after it", between, rank)
+	}
+}
+
+func TestRankBetweenRepeatedInserts(t *testing.T) {
+	// Inserting at the same position repeatedly lengthens ranks until the stage is rebalanced.
+	lower, upper := integerRank(1), integerRank(2)
+	for i := 0; i < 1000; i++ {
+		rank := rankBetween(lower, upper)
+		if rank <= lower || rank >= upper {
+			t.Fatalf("insert %d: rank %q not between %q and %q", i, rank, lower, upper)
+		}
+		if len(rank) > maxRankLength {
+			return
+		}
+		upper = rank
+	}
+	t.Errorf("ranks did not reach %d characters", maxRankLength)
+}
+
+func TestMoveTaskRebalancesLongRanks(t *testing.T) {
+	boardID, admin := newBoard(t)
+	first := newTask(t, boardID, admin, &CreateTaskParams{Title: "first"})
+	b := newTask(t, boardID, admin, &CreateTaskParams{Title: "b"})
+	c := newTask(t, boardID, admin, &CreateTaskParams{Title: "c"})
+
+	// Moving two tasks in turns directly after the first halves the gap between them each
+	// time, so their ranks grow until the stage is rebalanced.
+	moved, next := b, c
+	for i := 0; i < 500; i++ {
+		moved, next = next, moved
+		current, err := GetTask(as(admin), moved.ID)
+		if err != nil {
+			t.Fatalf("GetTask: %v", err)
+		}
+		if _, err := MoveTask(as(admin), moved.ID, &MoveTaskParams{AfterID: first.ID, BeforeID: next.ID, Version: current.Task.Version}); err != nil {
+			t.Fatalf("move %d: %v", i, err)
+		}
+	}
+
+	ids, ranks := stageRanks(t, boardID, first.Stage)
+	want := []string{first.ID, moved.ID, next.ID}
+	for i, rank := range ranks {
+		if len(rank) > maxRankLength {
+			t.Errorf("rank %q exceeds %d characters", rank, maxRankLength)
+		}
+		if i >= len(want) || ids[i] != want[i] {
+			t.Fatalf("tasks in order %v, want %v", ids, want)
+		}
+	}
+}
+
+func TestMoveTaskRequiresVersion(t *testing.T) {
+	boardID, admin := newBoard(t)
+	task := newTask(t, boardID, admin, &CreateTaskParams{})
+
+	_, err := MoveTask(as(admin), task.ID, &MoveTaskParams{Stage: "In Progress"})
+	if errs.Code(err) != errs.FailedPrecondition {
+		t.Errorf("move without version: got %v, want FailedPrecondition", err)
+	}
+	_, err = MoveTask(as(admin), task.ID, &MoveTaskParams{Stage: "In Progress", Version: task.Version + 1})
+	if errs.Code(err) != errs.Aborted {
+		t.Errorf("move with stale version: got %v, want Aborted", err)
+	}
+	_, err = MoveTask(as(admin), task.ID, &MoveTaskParams{Stage: "In Progress", IfMatch: taskETag(task.Version)})
+	if err != nil {
+		t.Errorf("move with If-Match: %v", err)
+	}
+}
+
+func TestListTasksRankSortUsesStageOrder(t *testing.T) {
+	boardID, admin := newBoard(t)
+	// The default stages are not in alphabetical order.
+	for _, stage := range []string{"Done", "To Do", "In Progress"} {
+		newTask(t, boardID, admin, &CreateTaskParams{Title: stage, Stage: stage})
+	}
+
+	resp, err := ListTasks(as(admin), boardID, &ListTasksParams{Sort: "rank", Limit: 2})
+	if err != nil {
+		t.Fatalf("ListTasks: %v", err)
+	}
+	next, err := ListTasks(as(admin), boardID, &ListTasksParams{Sort: "rank", Limit: 2, Cursor: resp.NextCursor})
+	if err != nil {
+		t.Fatalf("ListTasks with cursor: %v", err)
+	}
+	var stages []string
+	for _, task := range append(resp.Tasks, next.Tasks...) {
+		stages = append(stages, task.Stage)
+	}
+	want := []string{"To Do", "In Progress", "Done"}
+	if len(stages) != len(want) {
+		t.Fatalf("stages = %v, want %v", stages, want)
+	}
+	for i := range want {
+		if stages[i] != want[i] {
+			t.Fatalf("stages = %v, want %v", stages, want)
+		}
+	}
+}
+
+// stageRanks returns the ids and ranks of the tasks of a stage in order.
+func stageRanks(t *testing.T, boardID, stage string) (ids, ranks []string) {
+	t.Helper()
+	rows, err := taskDB.Query(context.Background(), `
+        SELECT id, rank FROM tasks
+        WHERE board_id = $1 AND stage = $2
+        ORDER BY rank
+    `, boardID, stage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id, rank string
+		if err := rows.Scan(&id, &rank); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+		ranks = append(ranks, rank)
+	}
+	return ids, ranks
+}
@@ -0,0 +1,78 @@
+package task
+
+import (
+	"context"
+
+	"encore.app/board"
+	"encore.dev/beta/errs"
+	"encore.dev/cron"
+	"encore.dev/rlog"
+)
+
+// reconcileBatchSize is the number of board ids checked per call to the board service.
+const reconcileBatchSize = 500
+
+// The reconcile-orphaned-tasks cron job deletes tasks whose board no longer exists, covering
+// board deletion events that were never delivered.
+var _ = cron.NewJob("reconcile-orphaned-tasks", cron.JobConfig{
+	Title:    "Delete tasks of deleted boards",
+	Every:    1 * cron.Hour,
+	Endpoint: ReconcileOrphanedTasks,
+})
+
+// ReconcileOrphanedTasksResponse summarizes a single reconciliation run.
+type ReconcileOrphanedTasksResponse struct {
+	Boards       int   `json:"boards"`        // number of deleted boards that still had tasks
+	DeletedTasks int64 `json:"deleted_tasks"` // number of orphaned tasks deleted
+}
+
+// ReconcileOrphanedTasks finds tasks referencing boards that no longer exist and deletes them.
+// It is invoked by the reconciliation cron job.
+//
+//encore:api private method=POST path=/internal/tasks/reconcile
+func ReconcileOrphanedTasks(ctx context.Context) (*ReconcileOrphanedTasksResponse, error) {
+	rows, err := taskDB.Query(ctx, `
+        SELECT DISTINCT board_id::text FROM tasks
+    `)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch task boards").Cause(err).Err()
+	}
+	defer rows.Close()
+
+	var boardIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to scan board id").Cause(err).Err()
+		}
+		boardIDs = append(boardIDs, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("error reading task boards").Cause(err).Err()
+	}
+
+	resp := &ReconcileOrphanedTasksResponse{}
+	for start := 0; start < len(boardIDs); start += reconcileBatchSize {
+		end := min(start+reconcileBatchSize, len(boardIDs))
+		missing, err := board.FindMissingBoards(ctx, &board.FindMissingBoardsParams{BoardIDs: boardIDs[start:end]})
+		if err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to look up boards").Cause(err).Err()
+		}
+
+		for _, boardID := range missing.Missing {
+			result, err := taskDB.Exec(ctx, `
+                DELETE FROM tasks
+                WHERE board_id = $1
+            `, boardID)
+			if err != nil {
+				return nil, errs.B().Code(errs.Internal).Msg("failed to delete orphaned tasks").Cause(err).Err()
+			}
+			rlog.Info("deleted orphaned tasks", "board_id", boardID, "tasks", result.RowsAffected())
+			resp.Boards++
+			resp.DeletedTasks += result.RowsAffected()
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,206 @@
+//go:build encore_app
+
+package task
+
+import (
+	"encoding/json"
+	"testing"
+
+	"encore.app/authz"
+	"encore.app/board"
+	"encore.dev/beta/auth"
+	"encore.dev/beta/errs"
+)
+
+// TestRolePermissions checks every built-in role and a custom role against the task endpoints,
+// acting on tasks created by another member.
+func TestRolePermissions(t *testing.T) {
+	boardID, admin := newBoard(t)
+	// Reviewers may read and update any task, but neither create nor delete tasks.
+	_, err := board.CreateRole(as(admin), boardID, &board.RoleParams{
+		Name:        "Reviewer",
+		Permissions: []string{authz.BoardView, authz.TaskView, authz.TaskUpdateAny},
+	})
+	if err != nil {
+		t.Fatalf("CreateRole: %v", err)
+	}
+	labels, err := CreateLabel(as(admin), boardID, &CreateLabelParams{Name: "bug", Color: "#d73a4a"})
+	if err != nil {
+		t.Fatalf("CreateLabel: %v", err)
+	}
+	labelID := labels.Labels[0].ID
+	// Fixture tasks carry labelID and the Admin as assignee; the calls adding a label or an
+	// assignee add ones the task does not have yet.
+	labels, err = CreateLabel(as(admin), boardID, &CreateLabelParams{Name: "feature", Color: "#a2eeef"})
+	if err != nil {
+		t.Fatalf("CreateLabel: %v", err)
+	}
+	var otherLabelID string
+	for _, l := range labels.Labels {
+		if l.ID != labelID {
+			otherLabelID = l.ID
+		}
+	}
+	member := addMember(t, boardID, admin, authz.RoleMember)
+
+	users := map[string]auth.UID{
+		authz.RoleAdmin:  admin,
+		authz.RoleMember: member,
+		authz.RoleViewer: addMember(t, boardID, admin, authz.RoleViewer),
+		"Reviewer":       addMember(t, boardID, admin, "Reviewer"),
+	}
+
+	const (
+		ok     = errs.OK
+		denied = errs.PermissionDenied
+	)
+	tests := []struct {
+		name string
+		call func(uid auth.UID, task *TaskResponse) error
+		want map[string]errs.ErrCode // by role
+	}{
+		{
+			name: "CreateTask",
+			call: func(uid auth.UID, _ *TaskResponse) error {
+				_, err := CreateTask(as(uid), &CreateTaskParams{BoardID: boardID, Title: "new"})
+				return err
+			},
+			want: map[string]errs.ErrCode{authz.RoleAdmin: ok, authz.RoleMember: ok, authz.RoleViewer: denied, "Reviewer": denied},
+		},
+		{
+			name: "UpdateTask",
+			call: func(uid auth.UID, task *TaskResponse) error {
+				_, err := UpdateTask(as(uid), task.ID, &UpdateTaskParams{Title: json.RawMessage(`"renamed"`), Version: task.Version})
+				return err
+			},
+			want: map[string]errs.ErrCode{authz.RoleAdmin: ok, authz.RoleMember: denied, authz.RoleViewer: denied, "Reviewer": ok},
+		},
+		{
+			name: "DeleteTask",
+			call: func(uid auth.UID, task *TaskResponse) error {
+				_, err := DeleteTask(as(uid), task.ID, &DeleteTaskParams{Version: task.Version})
+				return err
+			},
+			want: map[string]errs.ErrCode{authz.RoleAdmin: ok, authz.RoleMember: denied, authz.RoleViewer: denied, "Reviewer": denied},
+		},
+		{
+			name: "ListTasks",
+			call: func(uid auth.UID, _ *TaskResponse) error {
+				_, err := ListTasks(as(uid), boardID, &ListTasksParams{})
+				return err
+			},
+			want: map[string]errs.ErrCode{authz.RoleAdmin: ok, authz.RoleMember: ok, authz.RoleViewer: ok, "Reviewer": ok},
+		},
+		{
+			name: "GetTask",
+			call: func(uid auth.UID, task *TaskResponse) error {
+				_, err := GetTask(as(uid), task.ID)
+				return err
+			},
+			want: map[string]errs.ErrCode{authz.RoleAdmin: ok, authz.RoleMember: ok, authz.RoleViewer: ok, "Reviewer": ok},
+		},
+		{
+			name: "MoveTask",
+			call: func(uid auth.UID, task *TaskResponse) error {
+				_, err := MoveTask(as(uid), task.ID, &MoveTaskParams{Stage: "In Progress", Version: task.Version})
+				return err
+			},
+			want: map[string]errs.ErrCode{authz.RoleAdmin: ok, authz.RoleMember: denied, authz.RoleViewer: denied, "Reviewer": ok},
+		},
+		{
+			name: "AddAssignee",
+			call: func(uid auth.UID, task *TaskResponse) error {
+				_, err := AddAssignee(as(uid), task.ID, &AddAssigneeParams{UserID: string(member)})
+				return err
+			},
+			want: map[string]errs.ErrCode{authz.RoleAdmin: ok, authz.RoleMember: denied, authz.RoleViewer: denied, "Reviewer": ok},
+		},
+		{
+			name: "RemoveAssignee",
+			call: func(uid auth.UID, task *TaskResponse) error {
+				_, err := RemoveAssignee(as(uid), task.ID, task.AssigneeIDs[0])
+				return err
+			},
+			want: map[string]errs.ErrCode{authz.RoleAdmin: ok, authz.RoleMember: denied, authz.RoleViewer: denied, "Reviewer": ok},
+		},
+		{
+			name: "AddTaskLabel",
+			call: func(uid auth.UID, task *TaskResponse) error {
+				_, err := AddTaskLabel(as(uid), task.ID, &AddTaskLabelParams{LabelID: otherLabelID})
+				return err
+			},
+			want: map[string]errs.ErrCode{authz.RoleAdmin: ok, authz.RoleMember: denied, authz.RoleViewer: denied, "Reviewer": ok},
+		},
+		{
+			name: "RemoveTaskLabel",
+			call: func(uid auth.UID, task *TaskResponse) error {
+				_, err := RemoveTaskLabel(as(uid), task.ID, task.LabelIDs[0])
+				return err
+			},
+			want: map[string]errs.ErrCode{authz.RoleAdmin: ok, authz.RoleMember: denied, authz.RoleViewer: denied, "Reviewer": ok},
+		},
+	}
+
+	for _, tt := range tests {
+		for role, uid := range users {
+			t.Run(tt.name+"/"+role, func(t *testing.T) {
+				// Every call acts on a fresh task created by the Admin.
+				task := newTask(t, boardID, admin, &CreateTaskParams{AssigneeIDs: []string{string(admin)}, LabelIDs: []string{labelID}})
+				err := tt.call(uid, task)
+				if got := errs.Code(err); got != tt.want[role] {
+					t.Errorf("got %v (%v), want %v", got, err, tt.want[role])
+				}
+			})
+		}
+	}
+}
+
+// TestMemberOwnTasks checks that Members may change and delete the tasks they created.
+func TestMemberOwnTasks(t *testing.T) {
+	boardID, admin := newBoard(t)
+	member := addMember(t, boardID, admin, authz.RoleMember)
+
+	task := newTask(t, boardID, member, &CreateTaskParams{})
+	updated, err := UpdateTask(as(member), task.ID, &UpdateTaskParams{Title: json.RawMessage(`"renamed"`), Version: task.Version})
+	if err != nil {
+		t.Fatalf("UpdateTask: %v", err)
+	}
+	if _, err := AddAssignee(as(member), task.ID, &AddAssigneeParams{UserID: string(member)}); err != nil {
+		t.Fatalf("AddAssignee: %v", err)
+	}
+	assigned, err := GetTask(as(member), task.ID)
+	if err != nil {
+		t.Fatalf("GetTask: %v", err)
+	}
+	if _, err := MoveTask(as(member), task.ID, &MoveTaskParams{Stage: "In Progress", Version: assigned.Task.Version}); err != nil {
+		t.Fatalf("MoveTask: %v", err)
+	}
+	current, err := GetTask(as(member), task.ID)
+	if err != nil {
+		t.Fatalf("GetTask: %v", err)
+	}
+	if current.Task.Version <= updated.Version {
+		t.Errorf("version %d not incremented past %d", current.Task.Version, updated.Version)
+	}
+	if _, err := DeleteTask(as(member), task.ID, &DeleteTaskParams{Version: current.Task.Version}); err != nil {
+		t.Fatalf("DeleteTask: %v", err)
+	}
+}
+
+// TestTaskExistenceHidden checks that users who may not view a task cannot tell it from a
+// task that does not exist.
+func TestTaskExistenceHidden(t *testing.T) {
+	boardID, admin := newBoard(t)
+	task := newTask(t, boardID, admin, &CreateTaskParams{})
+	outsider := newUserID(t)
+	missing := "00000000-0000-4000-8000-000000000000"
+
+	for _, taskID := range []string{task.ID, missing} {
+		if _, err := GetTask(as(outsider), taskID); errs.Code(err) != errs.NotFound {
+			t.Errorf("GetTask(%s): got %v, want NotFound", taskID, err)
+		}
+		if _, err := GetTaskHistory(as(outsider), taskID); errs.Code(err) != errs.NotFound {
+			t.Errorf("GetTaskHistory(%s): got %v, want NotFound", taskID, err)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package task
+
+import (
+	"context"
+	"html"
+	"strings"
+	"unicode"
+
+	"encore.app/authz"
+	"encore.dev/beta/auth"
+	"encore.dev/beta/errs"
+)
+
+// maxSearchLimit is the maximum number of search results returned at once.
+const maxSearchLimit = 100
+
+// Options of ts_headline for highlighting matches: the whole title is returned, while
+// descriptions are cut down to the fragments around matches. Matches are delimited by
+// private-use characters, which are removed from task text beforehand, so that the text can be
+// HTML-escaped before the delimiters are replaced by <mark> tags.
+const (
+	highlightStart         = "\ue000"
+	highlightStop          = "\ue001"
+	titleHeadlineOptions   = "StartSel=\"" + highlightStart + "\", StopSel=\"" + highlightStop + "\", HighlightAll=true"
+	snippetHeadlineOptions = "StartSel=\"" + highlightStart + "\", StopSel=\"" + highlightStop + "\", MaxFragments=2, MaxWords=20, MinWords=8, FragmentDelimiter=\" ... \""
+)
+
+// highlightMarks replaces the match delimiters of ts_headline with <mark> tags.
+var highlightMarks = strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>")
+
+// SearchTasksParams defines the query parameters for searching tasks.
+type SearchTasksParams struct {
+	Q      string `query:"q"`                  // search words; the last characters of each word may be omitted
+	Limit  int    `query:"limit" default:"20"` // Number of results to return, at most 100
+	Offset int    `query:"offset" default:"0"` // Number of results to skip
+}
+
+// TaskSearchResult represents a task matching a search.
+type TaskSearchResult struct {
+	ID             string  `json:"id"`                // task id
+	BoardID        string  `json:"board_id"`          // board id
+	BoardName      string  `json:"board_name"`        // name of the board
+	Title          string  `json:"title"`             // task title
+	TitleHighlight string  `json:"title_highlight"`   // HTML-escaped title with matches wrapped in <mark> tags
+	Snippet        string  `json:"snippet,omitempty"` // HTML-escaped description fragments with matches wrapped in <mark> tags
+	Stage          string  `json:"stage"`             // task stage
+	Rank           float64 `json:"rank"`              // relevance, higher is better
+}
+
+// SearchTasksResponse represents a page of search results, most relevant first.
+type SearchTasksResponse struct {
+	Results []TaskSearchResult `json:"results"` // matching tasks
+	Total   int                `json:"total"`   // Total number of matching tasks
+}
+
+// SearchTasks searches the titles and descriptions of tasks on all boards where the
+// authenticated user has the task.view permission. Words match by prefix and stem, and title
+// matches rank above description matches. Highlights and snippets are HTML: task text is
+// escaped, and matches are wrapped in <mark> tags. Text the search parser reads as an HTML tag,
+// such as "<b>", is left out of highlights and snippets.
+//
+//encore:api auth method=GET path=/search/tasks
+func SearchTasks(ctx context.Context, p *SearchTasksParams) (*SearchTasksResponse, error) {
+	_, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	query := searchQuery(p.Q)
+	if query == "" {
+		return nil, errs.B().Code(errs.InvalidArgument).Msg("q must contain at least one word").Err()
+	}
+	if p.Limit <= 0 || p.Limit > maxSearchLimit || p.Offset < 0 {
+		return nil, errs.B().Code(errs.InvalidArgument).Msgf("limit must be between 1 and %d and offset non-negative", maxSearchLimit).Err()
+	}
+
+	boards, err := memberBoards(ctx, authz.TaskView)
+	if err != nil {
+		return nil, err
+	}
+	boardNames := make(map[string]string, len(boards))
+	boardIDs := make([]string, 0, len(boards))
+	for _, b := range boards {
+		boardNames[b.BoardID] = b.BoardName
+		boardIDs = append(boardIDs, b.BoardID)
+	}
+
+	resp := &SearchTasksResponse{Results: []TaskSearchResult{}}
+	if len(boardIDs) == 0 {
+		return resp, nil
+	}
+
+	err = taskDB.QueryRow(ctx, `
+        SELECT COUNT(*)
+        FROM tasks
+        WHERE board_id::text = ANY($1::text[]) AND search_vector @@ to_tsquery('english', $2)
+    `, boardIDs, query).Scan(&resp.Total)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to count search results").Cause(err).Err()
+	}
+
+	// Search results are ranked by relevance, which does not make a stable cursor; pages are
+	// addressed by offset instead.
+	rows, err := taskDB.Query(ctx, `
+        SELECT t.id, t.board_id, t.title, t.stage,
+               ts_headline('english', translate(t.title, $7, ''), q.query, $3),
+               ts_headline('english', translate(COALESCE(t.description, ''), $7, ''), q.query, $4),
+               ts_rank(t.search_vector, q.query) AS relevance
+        FROM tasks t, to_tsquery('english', $2) AS q(query)
+        WHERE t.board_id::text = ANY($1::text[]) AND t.search_vector @@ q.query
+        ORDER BY relevance DESC, t.updated_at DESC, t.id
+        LIMIT $5 OFFSET $6
+    `, boardIDs, query, titleHeadlineOptions, snippetHeadlineOptions, p.Limit, p.Offset, highlightStart+highlightStop)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to search tasks").Cause(err).Err()
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r TaskSearchResult
+		if err := rows.Scan(&r.ID, &r.BoardID, &r.Title, &r.Stage, &r.TitleHighlight, &r.Snippet, &r.Rank); err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to scan search result").Cause(err).Err()
+		}
+		r.TitleHighlight = highlight(r.TitleHighlight)
+		r.Snippet = highlight(r.Snippet)
+		r.BoardName = boardNames[r.BoardID]
+		resp.Results = append(resp.Results, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("error reading search results").Cause(err).Err()
+	}
+
+	return resp, nil
+}
+
+// searchQuery turns search words into a tsquery matching tasks that contain all words, each
+// as a prefix. Characters other than letters and digits separate words, so the result is
+// always valid tsquery syntax. It returns "" if text contains no words.
+func searchQuery(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		words[i] = w + ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
+// highlight turns the output of ts_headline into HTML: the text is escaped and the matches are
+// wrapped in <mark> tags.
+func highlight(headline string) string {
+	return highlightMarks.Replace(html.EscapeString(headline))
+}
@@ -0,0 +1,45 @@
+//go:build encore_app
+
+package task
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSearchTasksEscapesHighlights(t *testing.T) {
+	boardID, admin := newBoard(t)
+	newTask(t, boardID, admin, &CreateTaskParams{
+		Title:       "Fix xylophone tuning when a < b && c > d",
+		Description: "The <img src=x onerror=alert(1)> xylophone keys stick if a < b",
+	})
+
+	resp, err := SearchTasks(as(admin), &SearchTasksParams{Q: "xylophone", Limit: 20})
+	if err != nil {
+		t.Fatalf("SearchTasks: %v", err)
+	}
+	if len(resp.Results) != 1 {
+		t.Fatalf("got %d results, want 1", len(resp.Results))
+	}
+	r := resp.Results[0]
+	for name, got := range map[string]string{"title_highlight": r.TitleHighlight, "snippet": r.Snippet} {
+		if strings.Contains(got, "<img") || strings.Contains(got, " < ") {
+			t.Errorf("%s contains unescaped HTML: %q", name, got)
+		}
+		if !strings.Contains(got, "<mark>xylophone</mark>") {
+			t.Errorf("%s does not highlight the match: %q", name, got)
+		}
+		if n := strings.Count(got, "<mark>"); n != 1 {
+			t.Errorf("%s has %d highlights, want 1: %q", name, n, got)
+		}
+	}
+	// Tags are dropped by the search parser, while other markup characters are escaped.
+	for _, escaped := range []string{"&lt;", "&amp;&amp;", "&gt;"} {
+		if !strings.Contains(r.TitleHighlight, escaped) {
+			t.Errorf("title_highlight = %q, want it to contain %q", r.TitleHighlight, escaped)
+		}
+	}
+	if !strings.Contains(r.Snippet, "&lt;") {
+		t.Errorf("snippet = %q, want escaped task text", r.Snippet)
+	}
+}
@@ -0,0 +1,177 @@
+package task
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"encore.app/board"
+	"encore.dev/beta/errs"
+	"encore.dev/pubsub"
+)
+
+// templateLabelColor is the color of template labels that do not define one.
+const templateLabelColor = "#ededed"
+
+// Subscribes to the BoardSeedTopic to populate cloned boards and boards created from a
+// template with tasks and labels.
+var _ = pubsub.NewSubscription(
+	board.BoardSeedTopic, "seed-tasks-on-board-creation",
+	pubsub.SubscriptionConfig[*board.BoardSeedEvent]{
+		Handler: handleBoardSeedEvent,
+	},
+)
+
+// board-seed event handler
+func handleBoardSeedEvent(ctx context.Context, event *board.BoardSeedEvent) error {
+	layout, err := board.GetStageLayout(ctx, event.BoardID)
+	if err != nil {
+		if errs.Code(err) == errs.NotFound {
+			// The board was deleted before it could be seeded.
+			return nil
+		}
+		return errs.B().Code(errs.Internal).Msg("failed to fetch stage layout").Cause(err).Err()
+	}
+	stageIDs := make(map[string]string, len(layout.Stages))
+	for _, s := range layout.Stages {
+		stageIDs[s.Name] = s.ID
+	}
+	if event.SourceBoardID != "" {
+		// Source tasks are selected by stage name, so their stages must be current.
+		if err := refreshStages(ctx, event.SourceBoardID); err != nil && errs.Code(err) != errs.NotFound {
+			return err
+		}
+	}
+
+	tx, err := taskDB.Begin(ctx)
+	if err != nil {
+		return errs.B().Code(errs.Internal).Msg("failed to start transaction").Cause(err).Err()
+	}
+	defer tx.Rollback()
+
+	// The event is delivered at least once; only the first delivery creates tasks.
+	result, err := tx.Exec(ctx, `
+        INSERT INTO board_seeds (board_id)
+        VALUES ($1)
+        ON CONFLICT DO NOTHING
+    `, event.BoardID)
+	if err != nil {
+		return errs.B().Code(errs.Internal).Msg("failed to record board seed").Cause(err).Err()
+	}
+	if result.RowsAffected() == 0 {
+		return nil
+	}
+
+	if event.SourceBoardID != "" {
+		// Labels are copied by name so copied tasks can keep their labels.
+		_, err = tx.Exec(ctx, `
+            INSERT INTO labels (board_id, name, color)
+            SELECT $1, name, color FROM labels
+            WHERE board_id = $2
+        `, event.BoardID, event.SourceBoardID)
+		if err != nil {
+			return errs.B().Code(errs.Internal).Msg("failed to copy labels").Cause(err).Err()
+		}
+
+		// Copy tasks one by one so each copy can take over the labels and assignees of its source task.
+		rows, err := tx.Query(ctx, `
+            SELECT id, title, description, stage, rank, start_date, due_date, priority
+            FROM tasks
+            WHERE board_id = $1 AND (COALESCE(cardinality($2::text[]), 0) = 0 OR stage = ANY($2::text[]))
+            ORDER BY created_at
+        `, event.SourceBoardID, event.SourceStages)
+		if err != nil {
+			return errs.B().Code(errs.Internal).Msg("failed to fetch source tasks").Cause(err).Err()
+		}
+		type sourceTask struct {
+			id, title, description, stage, rank, priority string
+			startDate, dueDate                            *time.Time
+		}
+		var sources []sourceTask
+		for rows.Next() {
+			var t sourceTask
+			if err := rows.Scan(&t.id, &t.title, &t.description, &t.stage, &t.rank, &t.startDate, &t.dueDate, &t.priority); err != nil {
+				rows.Close()
+				return errs.B().Code(errs.Internal).Msg("failed to scan source task").Cause(err).Err()
+			}
+			sources = append(sources, t)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return errs.B().Code(errs.Internal).Msg("error reading source tasks").Cause(err).Err()
+		}
+
+		for _, t := range sources {
+			var id string
+			err := tx.QueryRow(ctx, `
+                INSERT INTO tasks (board_id, title, description, created_by, stage, stage_id, rank, start_date, due_date, priority, created_at, updated_at)
+                VALUES ($1, $2, $3, $4, $5, NULLIF($6, '')::uuid, $7, $8, $9, $10, NOW(), NOW())
+                RETURNING id
+            `, event.BoardID, t.title, t.description, event.CreatedBy, t.stage, stageIDs[t.stage], t.rank, t.startDate, t.dueDate, t.priority).Scan(&id)
+			if err != nil {
+				return errs.B().Code(errs.Internal).Msg("failed to copy task").Cause(err).Err()
+			}
+			_, err = tx.Exec(ctx, `
+                INSERT INTO task_labels (task_id, label_id)
+                SELECT $1, n.id
+                FROM task_labels tl
+                JOIN labels o ON o.id = tl.label_id
+                JOIN labels n ON n.board_id = $3 AND n.name = o.name
+                WHERE tl.task_id = $2
+            `, id, t.id, event.BoardID)
+			if err != nil {
+				return errs.B().Code(errs.Internal).Msg("failed to copy task labels").Cause(err).Err()
+			}
+			if !event.KeepAssignees {
+				continue
+			}
+			_, err = tx.Exec(ctx, `
+                INSERT INTO task_assignees (task_id, user_id)
+                SELECT $1, user_id FROM task_assignees
+                WHERE task_id = $2
+            `, id, t.id)
+			if err != nil {
+				return errs.B().Code(errs.Internal).Msg("failed to copy task assignees").Cause(err).Err()
+			}
+		}
+	}
+
+	for _, l := range event.Labels {
+		// Templates saved before labels were validated may hold labels the board cannot have.
+		name, err := validateLabelName(l.Name)
+		if err != nil {
+			continue
+		}
+		color := strings.ToLower(l.Color)
+		if !labelColorPattern.MatchString(color) {
+			color = templateLabelColor
+		}
+		_, err = tx.Exec(ctx, `
+            INSERT INTO labels (board_id, name, color)
+            VALUES ($1, $2, $3)
+            ON CONFLICT DO NOTHING
+        `, event.BoardID, name, color)
+		if err != nil {
+			return errs.B().Code(errs.Internal).Msg("failed to create template label").Cause(err).Err()
+		}
+	}
+
+	for _, t := range event.StarterTasks {
+		rank, err := nextRank(ctx, tx, event.BoardID, t.Stage)
+		if err != nil {
+			return errs.B().Code(errs.Internal).Msg("failed to rank starter task").Cause(err).Err()
+		}
+		_, err = tx.Exec(ctx, `
+            INSERT INTO tasks (board_id, title, description, created_by, stage, stage_id, rank, created_at, updated_at)
+            VALUES ($1, $2, $3, $4, $5, NULLIF($6, '')::uuid, $7, NOW(), NOW())
+        `, event.BoardID, t.Title, t.Description, event.CreatedBy, t.Stage, stageIDs[t.Stage], rank)
+		if err != nil {
+			return errs.B().Code(errs.Internal).Msg("failed to create starter task").Cause(err).Err()
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errs.B().Code(errs.Internal).Msg("failed to commit transaction").Cause(err).Err()
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+//go:build encore_app
+
+package task
+
+import (
+	"context"
+	"testing"
+
+	"encore.app/board"
+)
+
+func TestHandleBoardSeedEventCreatesTemplateLabels(t *testing.T) {
+	boardID, admin := newBoard(t)
+	event := &board.BoardSeedEvent{
+		BoardID:   boardID,
+		CreatedBy: string(admin),
+		Labels: []board.TemplateLabel{
+			{Name: "bug", Color: "#D73A4A"},
+			{Name: "chore"},
+		},
+	}
+	// Redelivered events must not create the labels twice.
+	for i := 0; i < 2; i++ {
+		if err := handleBoardSeedEvent(context.Background(), event); err != nil {
+			t.Fatalf("handleBoardSeedEvent: %v", err)
+		}
+	}
+
+	labels, err := ListLabels(as(admin), boardID)
+	if err != nil {
+		t.Fatalf("ListLabels: %v", err)
+	}
+	colors := make(map[string]string)
+	for _, l := range labels.Labels {
+		colors[l.Name] = l.Color
+	}
+	want := map[string]string{"bug": "#d73a4a", "chore": templateLabelColor}
+	if len(colors) != len(want) {
+		t.Fatalf("labels = %v, want %v", colors, want)
+	}
+	for name, color := range want {
+		if colors[name] != color {
+			t.Errorf("label %q has color %q, want %q", name, colors[name], color)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package task
+
+import (
+	"context"
+
+	"encore.app/board"
+	"encore.dev/beta/errs"
+	"encore.dev/pubsub"
+)
+
+// Subscribes to the StageChangedTopic to update tasks when a stage is renamed or deleted.
+var _ = pubsub.NewSubscription(
+	board.StageChangedTopic, "move-tasks-on-stage-change",
+	pubsub.SubscriptionConfig[*board.StageChangedEvent]{
+		Handler: handleStageChangedEvent,
+	},
+)
+
+// stage-changed event handler
+func handleStageChangedEvent(ctx context.Context, event *board.StageChangedEvent) error {
+	err := syncStages(ctx, event.BoardID, event.Version)
+	if errs.Code(err) == errs.NotFound {
+		// The board was deleted; its tasks are removed by the board-delete event.
+		return nil
+	}
+	return err
+}
+
+// syncStages brings the stages recorded on a board's tasks in line with the board's stage
+// layout if they reflect a version older than version. Requests that read or change tasks sync
+// their board first, so they never see tasks in a stage the board no longer has, even while a
+// stage change event is still pending.
+func syncStages(ctx context.Context, boardID string, version int) error {
+	var synced int
+	err := taskDB.QueryRow(ctx, `
+        SELECT COALESCE(MAX(version), 0) FROM board_stage_syncs
+        WHERE board_id = $1
+    `, boardID).Scan(&synced)
+	if err != nil {
+		return errs.B().Code(errs.Internal).Msg("failed to fetch stage sync").Cause(err).Err()
+	}
+	if synced >= version {
+		return nil
+	}
+	return refreshStages(ctx, boardID)
+}
+
+// syncBoardStages syncs the stages of the tasks of several boards, see syncStages.
+func syncBoardStages(ctx context.Context, boards []board.MemberBoard) error {
+	for _, b := range boards {
+		if err := syncStages(ctx, b.BoardID, b.StagesVersion); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// refreshStages fetches the current stage layout of a board and applies it to the board's
+// tasks: tasks of deleted stages move to the stage that received them, and every task takes the
+// current name of its stage. Applying a layout is idempotent, and a layout older than the one
+// last applied is ignored, so concurrent and repeated syncs are harmless.
+func refreshStages(ctx context.Context, boardID string) error {
+	layout, err := board.GetStageLayout(ctx, boardID)
+	if err != nil {
+		if errs.Code(err) == errs.NotFound {
+			return err
+		}
+		return errs.B().Code(errs.Internal).Msg("failed to fetch stage layout").Cause(err).Err()
+	}
+
+	ids := make([]string, len(layout.Stages))
+	names := make([]string, len(layout.Stages))
+	for i, s := range layout.Stages {
+		ids[i], names[i] = s.ID, s.Name
+	}
+	from := make([]string, len(layout.Redirects))
+	to := make([]string, len(layout.Redirects))
+	for i, r := range layout.Redirects {
+		from[i], to[i] = r.From, r.To
+	}
+
+	tx, err := taskDB.Begin(ctx)
+	if err != nil {
+		return errs.B().Code(errs.Internal).Msg("failed to start transaction").Cause(err).Err()
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(ctx, `
+        SELECT pg_advisory_xact_lock(hashtext('stages/' || $1))
+    `, boardID)
+	if err != nil {
+		return errs.B().Code(errs.Internal).Msg("failed to lock board stages").Cause(err).Err()
+	}
+	var synced int
+	err = tx.QueryRow(ctx, `
+        SELECT COALESCE(MAX(version), 0) FROM board_stage_syncs
+        WHERE board_id = $1
+    `, boardID).Scan(&synced)
+	if err != nil {
+		return errs.B().Code(errs.Internal).Msg("failed to fetch stage sync").Cause(err).Err()
+	}
+	if synced >= layout.Version {
+		return nil
+	}
+
+	// Tasks created before stage ids were recorded are matched to their stage by name.
+	_, err = tx.Exec(ctx, `
+        UPDATE tasks t
+        SET stage_id = s.id
+        FROM unnest($2::uuid[], $3::text[]) AS s(id, name)
+        WHERE t.board_id = $1 AND t.stage_id IS NULL AND t.stage = s.name
+    `, boardID, ids, names)
+	if err != nil {
+		return errs.B().Code(errs.Internal).Msg("failed to match tasks to stages").Cause(err).Err()
+	}
+
+	_, err = tx.Exec(ctx, `
+        UPDATE tasks t
+        SET stage_id = r.moved_to
+        FROM unnest($2::uuid[], $3::uuid[]) AS r(stage_id, moved_to)
+        WHERE t.board_id = $1 AND t.stage_id = r.stage_id
+    `, boardID, from, to)
+	if err != nil {
+		return errs.B().Code(errs.Internal).Msg("failed to move tasks of deleted stages").Cause(err).Err()
+	}
+
+	_, err = tx.Exec(ctx, `
+        UPDATE tasks t
+        SET stage = s.name, version = t.version + 1, updated_at = NOW()
+        FROM unnest($2::uuid[], $3::text[]) AS s(id, name)
+        WHERE t.board_id = $1 AND t.stage_id = s.id AND t.stage <> s.name
+    `, boardID, ids, names)
+	if err != nil {
+		return errs.B().Code(errs.Internal).Msg("failed to rename task stages").Cause(err).Err()
+	}
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO board_stage_syncs (board_id, version, synced_at)
+        VALUES ($1, $2, NOW())
+        ON CONFLICT (board_id) DO UPDATE
+        SET version = EXCLUDED.version, synced_at = EXCLUDED.synced_at
+    `, boardID, layout.Version)
+	if err != nil {
+		return errs.B().Code(errs.Internal).Msg("failed to record stage sync").Cause(err).Err()
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errs.B().Code(errs.Internal).Msg("failed to commit transaction").Cause(err).Err()
+	}
+	return nil
+}
+
+// stageID returns the id of the stage of a board with the given name, or "" if the board has
+// no such stage.
+func stageID(membership *board.CheckMembershipResponse, name string) string {
+	for i, s := range membership.Stages {
+		if s == name && i < len(membership.StageIDs) {
+			return membership.StageIDs[i]
+		}
+	}
+	return ""
+}
@@ -5,9 +5,15 @@ package task
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
+	"encore.app/authz"
 	"encore.app/board"
+	"encore.app/priority"
 	"encore.dev/beta/auth"
 	"encore.dev/beta/errs"
 	"encore.dev/pubsub"
@@ -37,32 +43,50 @@ func handleBoardDeleteEvent(ctx context.Context, event *board.BoardDeletedEvent)
 	if err != nil {
 		return errs.B().Code(errs.Internal).Msg("failed to delete tasks for board").Cause(err).Err()
 	}
+	_, err = taskDB.Exec(ctx, `
+		DELETE FROM labels
+		WHERE board_id = $1
+	`, event.BoardID)
+	if err != nil {
+		return errs.B().Code(errs.Internal).Msg("failed to delete labels for board").Cause(err).Err()
+	}
 	return nil
 }
 
 // CreateTaskParams defines the input parameters for creating a new task.
 type CreateTaskParams struct {
-	BoardID     string `json:"board_id"`              // target board id
-	Title       string `json:"title"`                 // task title
-	Description string `json:"description,omitempty"` // task description (optional)
-	AssigneeID  string `json:"assignee_id,omitempty"` // user id of assignee (optional)
-	Stage       string `json:"stage,omitempty"`       // stage of the task only ('To Do' -- default, 'In Progress', 'Done') (optional)
+	BoardID     string   `json:"board_id"`               // target board id
+	Title       string   `json:"title"`                  // task title
+	Description string   `json:"description,omitempty"`  // task description (optional)
+	AssigneeIDs []string `json:"assignee_ids,omitempty"` // user ids of assignees (optional)
+	Stage       string   `json:"stage,omitempty"`        // stage of the task, one of the board's stages; defaults to the first stage (optional)
+	StartDate   string   `json:"start_date,omitempty"`   // date work starts, YYYY-MM-DD (optional)
+	DueDate     string   `json:"due_date,omitempty"`     // date the task is due, YYYY-MM-DD (optional)
+	Priority    string   `json:"priority,omitempty"`     // task priority; defaults to the board's default priority (optional)
+	LabelIDs    []string `json:"label_ids,omitempty"`    // ids of labels of the board to attach (optional)
 }
 
 // TaskResponse represents the response returned when a task is created or updated.
 type TaskResponse struct {
-	ID          string `json:"id"`                    // task id
-	BoardID     string `json:"board_id"`              // target board id
-	Title       string `json:"title"`                 // task title
-	Description string `json:"description,omitempty"` // task description
-	CreatedBy   string `json:"created_by"`            // owner id
-	AssigneeID  string `json:"assignee_id,omitempty"` // user id of assignee
-	Stage       string `json:"stage,omitempty"`       // task stage
-	CreatedAt   string `json:"created_at"`            // time of task creation
-	UpdatedAt   string `json:"updated_at,omitempty"`  // time of last updation
+	ID          string   `json:"id"`                    // task id
+	BoardID     string   `json:"board_id"`              // target board id
+	Title       string   `json:"title"`                 // task title
+	Description string   `json:"description,omitempty"` // task description
+	CreatedBy   string   `json:"created_by"`            // owner id
+	AssigneeIDs []string `json:"assignee_ids"`          // user ids of assignees
+	LabelIDs    []string `json:"label_ids"`             // ids of attached labels
+	Stage       string   `json:"stage,omitempty"`       // task stage
+	Rank        string   `json:"rank"`                  // position of the task within its stage; tasks sort by rank byte-wise
+	StartDate   string   `json:"start_date,omitempty"`  // date work starts, YYYY-MM-DD
+	DueDate     string   `json:"due_date,omitempty"`    // date the task is due, YYYY-MM-DD
+	Priority    string   `json:"priority"`              // task priority: "urgent", "high", "medium", "low" or "none"
+	Version     int      `json:"version"`               // task version, incremented on every change
+	CreatedAt   string   `json:"created_at"`            // time of task creation
+	UpdatedAt   string   `json:"updated_at,omitempty"`  // time of last updation
+	Warnings    []string `json:"warnings,omitempty"`    // non-blocking issues, e.g. a soft WIP limit being exceeded
 }
 
-// CreateTask creates a new task on a board, restricted to Admins and Members.
+// CreateTask creates a new task on a board, restricted to users with the task.create permission.
 //
 //encore:api auth method=POST path=/task
 func CreateTask(ctx context.Context, p *CreateTaskParams) (*TaskResponse, error) {
@@ -75,148 +99,382 @@ func CreateTask(ctx context.Context, p *CreateTaskParams) (*TaskResponse, error)
 		return nil, errs.B().Code(errs.InvalidArgument).Msg("board_id and title are required").Err()
 	}
 
-	membership, err := board.CheckMembership(ctx, p.BoardID)
+	membership, err := authorize(ctx, p.BoardID, authz.TaskCreate)
 	if err != nil {
-		return nil, errs.B().Code(errs.Internal).Msg("failed to check membership").Cause(err).Err()
-	}
-	if !membership.IsMember {
-		return nil, errs.B().Code(errs.PermissionDenied).Msg("access denied: must be a board member").Err()
+		return nil, err
 	}
-	if membership.Role == "Viewer" {
-		return nil, errs.B().Code(errs.PermissionDenied).Msg("access denied: only Admins and Members can create tasks").Err()
+	if membership.Archived {
+		return nil, errs.B().Code(errs.FailedPrecondition).Msg("board is archived and read-only").Err()
 	}
 
 	stage := p.Stage
-	if stage == "" {
-		stage = "To Do"
+	if stage == "" && len(membership.Stages) > 0 {
+		stage = membership.Stages[0]
+	}
+	if !slices.Contains(membership.Stages, stage) {
+		return nil, invalidStageError(membership.Stages)
+	}
+	startDate, err := parseDate("start_date", p.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	dueDate, err := parseDate("due_date", p.DueDate)
+	if err != nil {
+		return nil, err
 	}
-	if stage != "To Do" && stage != "In Progress" && stage != "Done" {
-		return nil, errs.B().Code(errs.InvalidArgument).Msg("stage must be 'To Do', 'In Progress', or 'Done'").Err()
+	if err := validateDateRange(startDate, dueDate); err != nil {
+		return nil, err
+	}
+	prio := p.Priority
+	if prio == "" {
+		prio = membership.DefaultPriority
+	}
+	if err := validatePriority(prio); err != nil {
+		return nil, err
+	}
+	assigneeIDs := []string{}
+	for _, id := range p.AssigneeIDs {
+		if !slices.Contains(assigneeIDs, id) {
+			assigneeIDs = append(assigneeIDs, id)
+		}
+	}
+	if len(assigneeIDs) > 0 {
+		if err := validateAssignees(ctx, p.BoardID, assigneeIDs); err != nil {
+			return nil, err
+		}
+	}
+	labelIDs := []string{}
+	for _, id := range p.LabelIDs {
+		if !slices.Contains(labelIDs, id) {
+			labelIDs = append(labelIDs, id)
+		}
+	}
+	if len(labelIDs) > 0 {
+		if err := validateLabels(ctx, p.BoardID, labelIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := taskDB.Begin(ctx)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to start transaction").Cause(err).Err()
+	}
+	defer tx.Rollback()
+
+	warning, err := checkWIPLimit(ctx, tx, p.BoardID, stage)
+	if err != nil {
+		return nil, err
+	}
+	rank, err := nextRank(ctx, tx, p.BoardID, stage)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to rank task").Cause(err).Err()
 	}
 
 	var id string
-	now := time.Now().Format(time.RFC3339)
-	err = taskDB.QueryRow(ctx, `
-        INSERT INTO tasks (board_id, title, description, created_by, assignee_id, stage, created_at, updated_at)
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
-        RETURNING id
-    `, p.BoardID, p.Title, p.Description, uid, p.AssigneeID, stage, now).Scan(&id)
+	var version int
+	now := time.Now()
+	err = tx.QueryRow(ctx, `
+        INSERT INTO tasks (board_id, title, description, created_by, stage, stage_id, rank, start_date, due_date, priority, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $11)
+        RETURNING id, version
+    `, p.BoardID, p.Title, p.Description, uid, stage, stageID(membership, stage), rank, startDate, dueDate, prio, now).Scan(&id, &version)
 	if err != nil {
 		return nil, errs.B().Code(errs.Internal).Msg("failed to create task").Cause(err).Err()
 	}
 
+	if err := insertAssignees(ctx, tx, id, assigneeIDs, now); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to assign task").Cause(err).Err()
+	}
+	if err := insertLabels(ctx, tx, id, labelIDs); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to attach labels").Cause(err).Err()
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to commit transaction").Cause(err).Err()
+	}
+
+	var warnings []string
+	if warning != "" {
+		warnings = append(warnings, warning)
+	}
+
 	return &TaskResponse{
 		ID:          id,
 		BoardID:     p.BoardID,
 		Title:       p.Title,
 		Description: p.Description,
 		CreatedBy:   string(uid),
-		AssigneeID:  p.AssigneeID,
+		AssigneeIDs: assigneeIDs,
+		LabelIDs:    labelIDs,
 		Stage:       stage,
-		CreatedAt:   now,
+		Rank:        rank,
+		StartDate:   formatDate(startDate),
+		DueDate:     formatDate(dueDate),
+		Priority:    prio,
+		Version:     version,
+		CreatedAt:   now.Format(time.RFC3339),
+		Warnings:    warnings,
 	}, nil
 }
 
-// UpdateTaskParams defines the input parameters for updating an existing task.
+// UpdateTaskParams defines the changes to an existing task as a JSON merge patch: omitted
+// fields are kept, null clears a field, and any other value replaces it.
 type UpdateTaskParams struct {
-	Title       string `json:"title,omitempty"`       // new title
-	Description string `json:"description,omitempty"` // new description
-	AssigneeID  string `json:"assignee_id,omitempty"` // new assigned user id
-	Stage       string `json:"stage,omitempty"`       // new stage of the task
+	Title       json.RawMessage `json:"title,omitempty"`        // new title, cannot be null or empty
+	Description json.RawMessage `json:"description,omitempty"`  // new description, null clears it
+	Stage       json.RawMessage `json:"stage,omitempty"`        // new stage of the task, cannot be null
+	AssigneeIDs json.RawMessage `json:"assignee_ids,omitempty"` // replaces the assignees, null clears them
+	LabelIDs    json.RawMessage `json:"label_ids,omitempty"`    // replaces the attached labels, null clears them
+	StartDate   json.RawMessage `json:"start_date,omitempty"`   // new start date, YYYY-MM-DD, null clears it
+	DueDate     json.RawMessage `json:"due_date,omitempty"`     // new due date, YYYY-MM-DD, null clears it
+	Priority    json.RawMessage `json:"priority,omitempty"`     // new priority, null resets it to "none"
+	Version     int             `json:"version,omitempty"`      // version the change is based on, if If-Match is not set
+	IfMatch     string          `header:"If-Match"`             // ETag of the version the change is based on
 }
 
-// UpdateTask updates an existing task, restricted to Admins or the task creator.
+// UpdateTask updates an existing task, restricted to users with the task.update.any permission,
+// or task.update.own for the task creator. The change must name the task version it is based on;
+// if the task was changed since, an Aborted error carrying the current task is returned. The
+// response reflects the persisted task.
+//
+// PUT is still accepted for clients written against the earlier version of this endpoint. It
+// is not a full replacement: a PUT body is applied as a merge patch exactly like PATCH, so
+// omitted fields are kept.
 //
-//encore:api auth method=PUT path=/task/:taskID
+//encore:api auth method=PATCH,PUT path=/task/:taskID
 func UpdateTask(ctx context.Context, taskID string, p *UpdateTaskParams) (*TaskResponse, error) {
 	uid, ok := auth.UserID()
 	if !ok {
 		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
 	}
 
-	var boardID, createdBy, currentTitle, currentDesc, currentAssignee, currentStage string
-	var createdAt, updatedAt time.Time
-	err := taskDB.QueryRow(ctx, `
-        SELECT board_id, title, description, created_by, assignee_id, stage, created_at, updated_at
+	expected, err := expectedVersion(p.IfMatch, p.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	boardID, createdBy, err := taskOwner(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	membership, err := authorizeTask(ctx, boardID, createdBy, string(uid), authz.TaskUpdateOwn, authz.TaskUpdateAny)
+	if err != nil {
+		return nil, err
+	}
+	if membership.Archived {
+		return nil, errs.B().Code(errs.FailedPrecondition).Msg("board is archived and read-only").Err()
+	}
+
+	// The task is read after authorizing, which syncs the board's stages into it.
+	var currentTitle, currentDesc, currentStage, currentPriority string
+	var currentVersion int
+	var currentStart, currentDue *time.Time
+	var createdAt time.Time
+	err = taskDB.QueryRow(ctx, `
+        SELECT title, COALESCE(description, ''), stage, start_date, due_date, priority, version, created_at
         FROM tasks
-        WHERE id = $1
-    `, taskID).Scan(&boardID, &currentTitle, &currentDesc, &createdBy, &currentAssignee, &currentStage, &createdAt, &updatedAt)
+        WHERE id = $1 AND board_id = $2
+    `, taskID, boardID).Scan(&currentTitle, &currentDesc, &currentStage, &currentStart, &currentDue, &currentPriority, &currentVersion, &createdAt)
 	if err != nil {
 		if err == sqldb.ErrNoRows {
-			return nil, errs.B().Code(errs.NotFound).Msg("task not found").Err()
+			return nil, conflictError(ctx, taskID)
 		}
 		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch task").Cause(err).Err()
 	}
+	if currentVersion != expected {
+		return nil, conflictError(ctx, taskID)
+	}
 
-	membership, err := board.CheckMembership(ctx, boardID)
+	newTitle, err := patchString(p.Title, "title", currentTitle, false)
 	if err != nil {
-		return nil, errs.B().Code(errs.Internal).Msg("failed to check membership").Cause(err).Err()
+		return nil, err
+	}
+	if newTitle == "" {
+		return nil, errs.B().Code(errs.InvalidArgument).Msg("title cannot be empty").Err()
 	}
-	if !membership.IsMember {
-		return nil, errs.B().Code(errs.PermissionDenied).Msg("access denied: must be a board member to update task").Err()
+	newDesc, err := patchString(p.Description, "description", currentDesc, true)
+	if err != nil {
+		return nil, err
+	}
+	newStage, err := patchString(p.Stage, "stage", currentStage, false)
+	if err != nil {
+		return nil, err
 	}
-	if membership.Role != "Admin" && createdBy != string(uid) {
-		return nil, errs.B().Code(errs.PermissionDenied).Msg("access denied: only Admin or creator can update task").Err()
+	if !slices.Contains(membership.Stages, newStage) {
+		return nil, invalidStageError(membership.Stages)
+	}
+	newStart, err := patchDate(p.StartDate, "start_date", currentStart)
+	if err != nil {
+		return nil, err
+	}
+	newDue, err := patchDate(p.DueDate, "due_date", currentDue)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateDateRange(newStart, newDue); err != nil {
+		return nil, err
+	}
+	newPriority, err := patchString(p.Priority, "priority", currentPriority, true)
+	if err != nil {
+		return nil, err
+	}
+	if newPriority == "" {
+		newPriority = priority.None
+	}
+	if err := validatePriority(newPriority); err != nil {
+		return nil, err
 	}
 
-	newTitle := currentTitle
-	if p.Title != "" {
-		newTitle = p.Title
+	current, err := taskAssignees(ctx, taskID)
+	if err != nil {
+		return nil, err
 	}
-	newDesc := currentDesc
-	if p.Description != "" {
-		newDesc = p.Description
+	newAssignees, assigneesSet, err := patchStrings(p.AssigneeIDs, "assignee_ids", current.AssigneeIDs)
+	if err != nil {
+		return nil, err
 	}
-	newAssignee := currentAssignee
-	if p.AssigneeID != "" {
-		newAssignee = p.AssigneeID
+	if assigneesSet && len(newAssignees) > 0 {
+		if err := validateAssignees(ctx, boardID, newAssignees); err != nil {
+			return nil, err
+		}
 	}
-	newStage := currentStage
-	if p.Stage != "" {
-		if p.Stage != "To Do" && p.Stage != "In Progress" && p.Stage != "Done" {
-			return nil, errs.B().Code(errs.InvalidArgument).Msg("stage must be 'To Do', 'In Progress', or 'Done'").Err()
+	newLabels, labelsSet, err := patchStrings(p.LabelIDs, "label_ids", nil)
+	if err != nil {
+		return nil, err
+	}
+	if labelsSet && len(newLabels) > 0 {
+		if err := validateLabels(ctx, boardID, newLabels); err != nil {
+			return nil, err
 		}
-		newStage = p.Stage
 	}
-	newUpdatedAt := time.Now().Format(time.RFC3339)
 
-	_, err = taskDB.Exec(ctx, `
+	if newStage != currentStage {
+		if err := checkTransition(ctx, boardID, membership.Permissions, currentStage, newStage, newAssignees, newDesc); err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := taskDB.Begin(ctx)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to start transaction").Cause(err).Err()
+	}
+	defer tx.Rollback()
+
+	var warnings []string
+	// A task moved to another stage goes to the end of it; nil keeps the current rank.
+	var newRank *string
+	if newStage != currentStage {
+		warning, err := checkWIPLimit(ctx, tx, boardID, newStage)
+		if err != nil {
+			return nil, err
+		}
+		if warning != "" {
+			warnings = append(warnings, warning)
+		}
+		rank, err := nextRank(ctx, tx, boardID, newStage)
+		if err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to rank task").Cause(err).Err()
+		}
+		newRank = &rank
+	}
+
+	resp := &TaskResponse{ID: taskID, BoardID: boardID, CreatedBy: createdBy, Warnings: warnings}
+	var startDate, dueDate *time.Time
+	var updatedAt time.Time
+	err = tx.QueryRow(ctx, `
         UPDATE tasks
-        SET title = $1, description = $2, assignee_id = $3, stage = $4, updated_at = $5
-        WHERE id = $6
-    `, newTitle, newDesc, newAssignee, newStage, newUpdatedAt, taskID)
+        SET title = $1, description = $2, stage = $3, stage_id = $4, rank = COALESCE($5, rank), start_date = $6, due_date = $7,
+            priority = $8, version = version + 1, updated_at = NOW()
+        WHERE id = $9 AND version = $10
+        RETURNING title, description, stage, rank, start_date, due_date, priority, version, updated_at
+    `, newTitle, newDesc, newStage, stageID(membership, newStage), newRank, newStart, newDue, newPriority, taskID, expected).Scan(&resp.Title, &resp.Description, &resp.Stage, &resp.Rank, &startDate, &dueDate, &resp.Priority, &resp.Version, &updatedAt)
 	if err != nil {
+		if err == sqldb.ErrNoRows {
+			return nil, conflictError(ctx, taskID)
+		}
 		return nil, errs.B().Code(errs.Internal).Msg("failed to update task").Cause(err).Err()
 	}
 
-	return &TaskResponse{
-		ID:          taskID,
-		BoardID:     boardID,
-		Title:       newTitle,
-		Description: newDesc,
-		CreatedBy:   createdBy,
-		AssigneeID:  newAssignee,
-		Stage:       newStage,
-		CreatedAt:   createdAt.Format(time.RFC3339),
-		UpdatedAt:   newUpdatedAt,
-	}, nil
+	if assigneesSet {
+		_, err = tx.Exec(ctx, `
+            DELETE FROM task_assignees
+            WHERE task_id = $1 AND NOT (user_id::text = ANY($2::text[]))
+        `, taskID, newAssignees)
+		if err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to update assignees").Cause(err).Err()
+		}
+		if err := insertAssignees(ctx, tx, taskID, newAssignees, updatedAt); err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to update assignees").Cause(err).Err()
+		}
+	}
+
+	if labelsSet {
+		_, err = tx.Exec(ctx, `
+            DELETE FROM task_labels
+            WHERE task_id = $1 AND NOT (label_id::text = ANY($2::text[]))
+        `, taskID, newLabels)
+		if err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to update labels").Cause(err).Err()
+		}
+		if err := insertLabels(ctx, tx, taskID, newLabels); err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to update labels").Cause(err).Err()
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to commit transaction").Cause(err).Err()
+	}
+
+	assignees, err := taskAssignees(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	resp.AssigneeIDs = assignees.AssigneeIDs
+	labels, err := taskLabels(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	resp.LabelIDs = labels.LabelIDs
+	resp.StartDate = formatDate(startDate)
+	resp.DueDate = formatDate(dueDate)
+	resp.CreatedAt = createdAt.Format(time.RFC3339)
+	resp.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return resp, nil
 }
 
-// ListTasksParams defines the query parameters for filtering and paginating tasks.
+// ListTasksParams defines the query parameters for filtering, sorting and paginating tasks.
 type ListTasksParams struct {
-	Stage  string `query:"stage,omitempty"`    // Filter by stage: "To Do", "In Progress", "Done"
-	Limit  int    `query:"limit" default:"10"` // Number of tasks to return
-	Offset int    `query:"offset" default:"0"` // Number of tasks to skip
+	Stages      []string `query:"stage,omitempty"`        // Filter by stages of the board; repeat the parameter for several stages
+	AssigneeID  string   `query:"assignee_id,omitempty"`  // Filter by assignee, matching any of a task's assignees
+	CreatedBy   string   `query:"created_by,omitempty"`   // Filter by creator
+	Q           string   `query:"q,omitempty"`            // Filter by text contained in the title or description, ignoring case
+	CreatedFrom string   `query:"created_from,omitempty"` // Only tasks created at or after this RFC3339 time
+	CreatedTo   string   `query:"created_to,omitempty"`   // Only tasks created before this RFC3339 time
+	DueFrom     string   `query:"due_from,omitempty"`     // Only tasks due on or after this date, YYYY-MM-DD
+	DueTo       string   `query:"due_to,omitempty"`       // Only tasks due on or before this date, YYYY-MM-DD
+	Due         string   `query:"due,omitempty"`          // Filter by due date: "overdue", "this_week" or "none"
+	TZ          string   `query:"tz,omitempty"`           // IANA time zone that determines today for the due filter, defaults to UTC
+	Priority    string   `query:"priority,omitempty"`     // Filter by priority
+	LabelIDs    []string `query:"label_id,omitempty"`     // Filter by label ids; repeat the parameter for several labels
+	LabelMatch  string   `query:"label_match,omitempty"`  // "any" (default) matches tasks with any of the labels, "all" tasks with all of them
+	Sort        string   `query:"sort,omitempty"`         // "created_at" (default), "updated_at", "due_date" (tasks without due date last), "priority" (most urgent first), "title" or "rank" (by stage in column order, then manual order); prefix with "-" to reverse
+	Limit       int      `query:"limit" default:"10"`     // Number of tasks to return
+	Cursor      string   `query:"cursor,omitempty"`       // next_cursor of the previous page; must be used with the same sort
 }
 
-// ListTasksResponse represents a paginated list of tasks for a board.
+// ListTasksResponse represents a page of tasks of a board.
 type ListTasksResponse struct {
-	Tasks []TaskResponse `json:"tasks"` // List of tasks
-	Total int            `json:"total"` // Total number of matching tasks
+	Tasks      []TaskResponse `json:"tasks"`                 // List of tasks
+	Total      int            `json:"total"`                 // Total number of matching tasks
+	NextCursor string         `json:"next_cursor,omitempty"` // cursor of the next page, empty on the last page
 }
 
-// ListTasks retrieves a paginated list of tasks for a board, optionally filtered by stage,
-// accessible to Admins and Members only.
+// ListTasks retrieves a page of tasks of a board, accessible to users with the task.view
+// permission. Tasks can be filtered by stage, assignee, creator, text, creation and due date,
+// priority and labels, and sorted by several keys. Pages are addressed by cursor, so paging
+// stays consistent while tasks are added or removed.
 //
 //encore:api auth method=GET path=/board/:boardID/tasks
 func ListTasks(ctx context.Context, boardID string, p *ListTasksParams) (*ListTasksResponse, error) {
@@ -225,136 +483,221 @@ func ListTasks(ctx context.Context, boardID string, p *ListTasksParams) (*ListTa
 		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
 	}
 
-	membership, err := board.CheckMembership(ctx, boardID)
+	membership, err := authorize(ctx, boardID, authz.TaskView)
 	if err != nil {
-		return nil, errs.B().Code(errs.Internal).Msg("failed to check membership").Cause(err).Err()
-	}
-	if !membership.IsMember {
-		return nil, errs.B().Code(errs.PermissionDenied).Msg("access denied: must be a board member to list tasks").Err()
-	}
-	if membership.Role == "Viewer" {
-		return nil, errs.B().Code(errs.PermissionDenied).Msg("access denied: only Admins and Members can list tasks").Err()
+		return nil, err
 	}
 
-	if p.Stage != "" && p.Stage != "To Do" && p.Stage != "In Progress" && p.Stage != "Done" {
-		return nil, errs.B().Code(errs.InvalidArgument).Msg("stage must be 'To Do', 'In Progress', or 'Done'").Err()
+	if p.Limit <= 0 {
+		return nil, errs.B().Code(errs.InvalidArgument).Msg("limit must be positive").Err()
 	}
-	if p.Limit <= 0 || p.Offset < 0 {
-		return nil, errs.B().Code(errs.InvalidArgument).Msg("limit must be positive and offset non-negative").Err()
+	order, err := parseSort(p.Sort)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := listFilter(boardID, p, membership.Stages)
+	if err != nil {
+		return nil, err
 	}
 
 	// Count total tasks for pagination
 	var total int
-	countQuery := `
-        SELECT COUNT(*) FROM tasks
-        WHERE board_id = $1
-    `
-	if p.Stage != "" {
-		countQuery += " AND stage = $2"
-	}
-	countArgs := []any{boardID}
-	if p.Stage != "" {
-		countArgs = append(countArgs, p.Stage)
-	}
-	err = taskDB.QueryRow(ctx, countQuery, countArgs...).Scan(&total)
+	err = taskDB.QueryRow(ctx, "SELECT COUNT(*) FROM tasks WHERE "+filter.where(), filter.args...).Scan(&total)
 	if err != nil {
 		return nil, errs.B().Code(errs.Internal).Msg("failed to count tasks").Cause(err).Err()
 	}
 
-	// Fetch paginated tasks
-	query := `
-        SELECT id, board_id, title, description, created_by, assignee_id, stage, created_at, updated_at
+	page := filter.clone()
+	order = order.bind(page, membership.StageIDs)
+	if p.Cursor != "" {
+		c, err := decodeCursor(p.Cursor, order)
+		if err != nil {
+			return nil, err
+		}
+		if err := order.after(page, c); err != nil {
+			return nil, err
+		}
+	}
+	// Fetch one task more than requested to learn whether there is a next page.
+	limit := page.arg(p.Limit + 1)
+	query := fmt.Sprintf(`
+        SELECT id, board_id, title, description, created_by, stage, rank, start_date, due_date, priority, version, created_at, updated_at, %s
         FROM tasks
-        WHERE board_id = $1
-    `
-	args := []any{boardID}
-	if p.Stage != "" {
-		query += " AND stage = $2"
-		args = append(args, p.Stage)
-	}
-	query += " ORDER BY created_at LIMIT $2 OFFSET $3"
-	if p.Stage == "" {
-		query = `
-            SELECT id, board_id, title, description, created_by, assignee_id, stage, created_at, updated_at
-            FROM tasks
-            WHERE board_id = $1
-            ORDER BY created_at LIMIT $2 OFFSET $3
-        `
-		args = append(args, p.Limit, p.Offset)
-	} else {
-		args = append(args, p.Limit, p.Offset)
-	}
-
-	rows, err := taskDB.Query(ctx, query, args...)
+        WHERE %s
+        ORDER BY %s
+        LIMIT %s
+    `, order.values(), page.where(), order.orderBy(), limit)
+
+	rows, err := taskDB.Query(ctx, query, page.args...)
 	if err != nil {
 		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch tasks").Cause(err).Err()
 	}
 	defer rows.Close()
 
 	var tasks []TaskResponse
+	var sortValues [][]string
 	for rows.Next() {
 		var t TaskResponse
+		var startDate, dueDate *time.Time
 		var createdAt, updatedAt time.Time
-		if err := rows.Scan(&t.ID, &t.BoardID, &t.Title, &t.Description, &t.CreatedBy, &t.AssigneeID, &t.Stage, &createdAt, &updatedAt); err != nil {
+		var sortValue []string
+		if err := rows.Scan(&t.ID, &t.BoardID, &t.Title, &t.Description, &t.CreatedBy, &t.Stage, &t.Rank, &startDate, &dueDate, &t.Priority, &t.Version, &createdAt, &updatedAt, &sortValue); err != nil {
 			return nil, errs.B().Code(errs.Internal).Msg("failed to scan task").Cause(err).Err()
 		}
+		t.StartDate = formatDate(startDate)
+		t.DueDate = formatDate(dueDate)
 		t.CreatedAt = createdAt.Format(time.RFC3339)
 		t.UpdatedAt = updatedAt.Format(time.RFC3339)
 		tasks = append(tasks, t)
+		sortValues = append(sortValues, sortValue)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, errs.B().Code(errs.Internal).Msg("error reading tasks").Cause(err).Err()
 	}
 
+	var nextCursor string
+	if len(tasks) > p.Limit {
+		tasks = tasks[:p.Limit]
+		last := p.Limit - 1
+		nextCursor = (&cursor{Sort: order.sort, Values: sortValues[last], ID: tasks[last].ID}).encode()
+	}
+
+	if err := attachRelations(ctx, tasks); err != nil {
+		return nil, err
+	}
+
 	return &ListTasksResponse{
-		Tasks: tasks,
-		Total: total,
+		Tasks:      tasks,
+		Total:      total,
+		NextCursor: nextCursor,
 	}, nil
 }
 
+// attachRelations fills in the assignees and labels of a list of tasks.
+func attachRelations(ctx context.Context, tasks []TaskResponse) error {
+	taskIDs := make([]string, len(tasks))
+	for i, t := range tasks {
+		taskIDs[i] = t.ID
+	}
+
+	assignees, err := loadAssignees(ctx, taskIDs)
+	if err != nil {
+		return errs.B().Code(errs.Internal).Msg("failed to fetch assignees").Cause(err).Err()
+	}
+	labels, err := loadLabels(ctx, taskIDs)
+	if err != nil {
+		return errs.B().Code(errs.Internal).Msg("failed to fetch labels").Cause(err).Err()
+	}
+	for i := range tasks {
+		tasks[i].AssigneeIDs = assignees[tasks[i].ID]
+		if tasks[i].AssigneeIDs == nil {
+			tasks[i].AssigneeIDs = []string{}
+		}
+		tasks[i].LabelIDs = labels[tasks[i].ID]
+		if tasks[i].LabelIDs == nil {
+			tasks[i].LabelIDs = []string{}
+		}
+	}
+	return nil
+}
+
+// listFilter builds the filter selecting the tasks of a board that match the list parameters.
+// stages are the board's stages in column order.
+func listFilter(boardID string, p *ListTasksParams, stages []string) (*taskFilter, error) {
+	f := &taskFilter{}
+	f.add("board_id = ?", boardID)
+	if len(p.Stages) > 0 {
+		for _, stage := range p.Stages {
+			if !slices.Contains(stages, stage) {
+				return nil, invalidStageError(stages)
+			}
+		}
+		f.add("stage = ANY(?::text[])", p.Stages)
+	}
+	if p.AssigneeID != "" {
+		f.add("EXISTS (SELECT 1 FROM task_assignees a WHERE a.task_id = tasks.id AND a.user_id::text = ?)", p.AssigneeID)
+	}
+	if p.CreatedBy != "" {
+		f.add("created_by::text = ?", p.CreatedBy)
+	}
+	if p.Q != "" {
+		addTextFilter(f, p.Q)
+	}
+	if err := addTimeRange(f, "created_at", "created", p.CreatedFrom, p.CreatedTo); err != nil {
+		return nil, err
+	}
+	if err := addDateRange(f, "due_date", "due", p.DueFrom, p.DueTo); err != nil {
+		return nil, err
+	}
+	if p.Due != "" {
+		today, err := clientToday(p.TZ)
+		if err != nil {
+			return nil, err
+		}
+		// Tasks in the last stage count as completed.
+		if err := addDueFilter(f, p.Due, today, map[string]string{boardID: stages[len(stages)-1]}); err != nil {
+			return nil, err
+		}
+	}
+	if p.Priority != "" {
+		if err := validatePriority(p.Priority); err != nil {
+			return nil, err
+		}
+		f.add("priority = ?", p.Priority)
+	}
+	if len(p.LabelIDs) > 0 {
+		if err := addLabelFilter(f, p.LabelIDs, p.LabelMatch); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
 // DeleteTaskResponse represents the response when a task is deleted.
 type DeleteTaskResponse struct {
 	Message string `json:"message"`
 }
 
-// DeleteTask deletes a specific task, restricted to Admins or the task creator.
+// DeleteTaskParams names the task version a deletion is based on.
+type DeleteTaskParams struct {
+	Version int    `query:"version"`   // version the deletion is based on, if If-Match is not set
+	IfMatch string `header:"If-Match"` // ETag of the version the deletion is based on
+}
+
+// DeleteTask deletes a specific task, restricted to users with the task.delete.any permission,
+// or task.delete.own for the task creator. If the task was changed since the named version,
+// an Aborted error carrying the current task is returned.
 //
 //encore:api auth method=DELETE path=/task/:taskID
-func DeleteTask(ctx context.Context, taskID string) (*DeleteTaskResponse, error) {
+func DeleteTask(ctx context.Context, taskID string, p *DeleteTaskParams) (*DeleteTaskResponse, error) {
 	uid, ok := auth.UserID()
 	if !ok {
 		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
 	}
 
-	var boardID, createdBy string
-	err := taskDB.QueryRow(ctx, `
-        SELECT board_id, created_by
-        FROM tasks
-        WHERE id = $1
-    `, taskID).Scan(&boardID, &createdBy)
+	expected, err := expectedVersion(p.IfMatch, p.Version)
 	if err != nil {
-		if err == sqldb.ErrNoRows {
-			return nil, errs.B().Code(errs.NotFound).Msg("task not found").Err()
-		}
-		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch task").Cause(err).Err()
+		return nil, err
 	}
 
-	membership, err := board.CheckMembership(ctx, boardID)
+	boardID, createdBy, err := taskOwner(ctx, taskID)
 	if err != nil {
-		return nil, errs.B().Code(errs.Internal).Msg("failed to check membership").Cause(err).Err()
+		return nil, err
 	}
-	if !membership.IsMember {
-		return nil, errs.B().Code(errs.PermissionDenied).Msg("access denied: must be a board member to delete task").Err()
+
+	membership, err := authorizeTask(ctx, boardID, createdBy, string(uid), authz.TaskDeleteOwn, authz.TaskDeleteAny)
+	if err != nil {
+		return nil, err
 	}
-	if membership.Role != "Admin" && createdBy != string(uid) {
-		return nil, errs.B().Code(errs.PermissionDenied).Msg("access denied: only Admin or creator can delete task").Err()
+	if membership.Archived {
+		return nil, errs.B().Code(errs.FailedPrecondition).Msg("board is archived and read-only").Err()
 	}
 
 	result, err := taskDB.Exec(ctx, `
         DELETE FROM tasks
-        WHERE id = $1
-    `, taskID)
+        WHERE id = $1 AND version = $2
+    `, taskID, expected)
 	if err != nil {
 		return nil, errs.B().Code(errs.Internal).Msg("failed to delete task").Cause(err).Err()
 	}
@@ -362,8 +705,55 @@ func DeleteTask(ctx context.Context, taskID string) (*DeleteTaskResponse, error)
 	rowsAffected := result.RowsAffected()
 
 	if rowsAffected == 0 {
-		return nil, errs.B().Code(errs.NotFound).Msg("task not found").Err()
+		return nil, conflictError(ctx, taskID)
 	}
 
 	return &DeleteTaskResponse{Message: "Task deleted successfully"}, nil
 }
+
+// invalidStageError returns the error reported when a stage is not one of the board's stages.
+func invalidStageError(stages []string) error {
+	return errs.B().Code(errs.InvalidArgument).Msgf("stage must be one of: '%s'", strings.Join(stages, "', '")).Err()
+}
+
+// checkTransition verifies that a task may move from one stage to another under the board's
+// transition rules, given the caller's permissions and the task's values after the update.
+func checkTransition(ctx context.Context, boardID string, perms []string, from, to string, assigneeIDs []string, description string) error {
+	rules, err := board.ListTransitionRules(ctx, boardID)
+	if err != nil {
+		return errs.B().Code(errs.Internal).Msg("failed to fetch transitions").Cause(err).Err()
+	}
+	if len(rules.Transitions) == 0 {
+		return nil
+	}
+
+	var allowed []string
+	for _, r := range rules.Transitions {
+		if r.From != from {
+			continue
+		}
+		allowed = append(allowed, r.To)
+		if r.To != to {
+			continue
+		}
+
+		if r.AdminOnly && !authz.Has(perms, authz.TaskMoveRestricted) {
+			return errs.B().Code(errs.PermissionDenied).Msgf("moving tasks from '%s' to '%s' requires permission '%s'", from, to, authz.TaskMoveRestricted).Err()
+		}
+		var missing []string
+		for _, f := range r.RequiredFields {
+			if (f == "assignee_id" && len(assigneeIDs) == 0) || (f == "description" && description == "") {
+				missing = append(missing, f)
+			}
+		}
+		if len(missing) > 0 {
+			return errs.B().Code(errs.FailedPrecondition).Msgf("moving tasks from '%s' to '%s' requires: %s", from, to, strings.Join(missing, ", ")).Err()
+		}
+		return nil
+	}
+
+	if len(allowed) == 0 {
+		return errs.B().Code(errs.FailedPrecondition).Msgf("tasks cannot be moved out of '%s'", from).Err()
+	}
+	return errs.B().Code(errs.FailedPrecondition).Msgf("cannot move task from '%s' to '%s'; allowed next stages: '%s'", from, to, strings.Join(allowed, "', '")).Err()
+}
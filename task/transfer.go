@@ -0,0 +1,333 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"encore.app/authz"
+	"encore.app/board"
+	"encore.dev/beta/auth"
+	"encore.dev/beta/errs"
+)
+
+// TransferTaskParams defines the board a task is moved or copied to. Moves must name the task
+// version they are based on.
+type TransferTaskParams struct {
+	BoardID string `json:"board_id"`          // target board id
+	Stage   string `json:"stage,omitempty"`   // stage on the target board; defaults to the stage of the same name, or else the first stage
+	Version int    `json:"version,omitempty"` // version a move is based on, if If-Match is not set; ignored by copies
+	IfMatch string `header:"If-Match"`        // ETag of the version a move is based on; ignored by copies
+}
+
+// transfer describes how a task is placed on a target board.
+type transfer struct {
+	stage       string   // stage on the target board
+	stageID     string   // id of the stage
+	labelIDs    []string // target labels with the names of the task's labels
+	assigneeIDs []string // assignees who can be assigned tasks on the target board
+	warnings    []string // labels and assignees that were dropped
+}
+
+// MoveTaskToBoard moves a task to another board, keeping its id and history. It is restricted
+// to users with the task.delete.any permission on the task's board, or task.delete.own for the
+// task creator, and the task.create permission on the target board. Labels are mapped to the
+// target board's labels by name and dropped if it has none of that name; assignees who cannot
+// be assigned tasks on the target board are unassigned. The move must name the task version it
+// is based on; if the task was changed since, an Aborted error carrying the current task is
+// returned.
+//
+//encore:api auth method=POST path=/task/:taskID/move-to-board
+func MoveTaskToBoard(ctx context.Context, taskID string, p *TransferTaskParams) (*TaskResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	expected, err := expectedVersion(p.IfMatch, p.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	boardID, createdBy, err := taskOwner(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if p.BoardID == boardID {
+		return nil, errs.B().Code(errs.InvalidArgument).Msg("task is already on this board; use the move endpoint to change its stage").Err()
+	}
+
+	source, err := authorizeTask(ctx, boardID, createdBy, string(uid), authz.TaskDeleteOwn, authz.TaskDeleteAny)
+	if err != nil {
+		return nil, err
+	}
+	if source.Archived {
+		return nil, errs.B().Code(errs.FailedPrecondition).Msg("board is archived and read-only").Err()
+	}
+
+	t, err := loadTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if t.Version != expected {
+		return nil, conflictError(ctx, taskID)
+	}
+
+	plan, err := planTransfer(ctx, t, p)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := taskDB.Begin(ctx)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to start transaction").Cause(err).Err()
+	}
+	defer tx.Rollback()
+
+	warning, err := checkWIPLimit(ctx, tx, p.BoardID, plan.stage)
+	if err != nil {
+		return nil, err
+	}
+	rank, err := nextRank(ctx, tx, p.BoardID, plan.stage)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to rank task").Cause(err).Err()
+	}
+
+	result, err := tx.Exec(ctx, `
+        UPDATE tasks
+        SET board_id = $1, stage = $2, stage_id = $3, rank = $4, version = version + 1, updated_at = NOW()
+        WHERE id = $5 AND board_id = $6 AND version = $7
+    `, p.BoardID, plan.stage, plan.stageID, rank, taskID, boardID, expected)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to move task").Cause(err).Err()
+	}
+	if result.RowsAffected() == 0 {
+		return nil, conflictError(ctx, taskID)
+	}
+
+	_, err = tx.Exec(ctx, `
+        DELETE FROM task_labels
+        WHERE task_id = $1
+    `, taskID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to detach labels").Cause(err).Err()
+	}
+	if err := insertLabels(ctx, tx, taskID, plan.labelIDs); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to attach labels").Cause(err).Err()
+	}
+
+	_, err = tx.Exec(ctx, `
+        DELETE FROM task_assignees
+        WHERE task_id = $1 AND NOT (user_id::text = ANY($2::text[]))
+    `, taskID, plan.assigneeIDs)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to unassign task").Cause(err).Err()
+	}
+
+	err = recordHistory(ctx, tx, taskID, string(uid), actionMovedToBoard, map[string]string{
+		"from_board_id": t.BoardID,
+		"from_stage":    t.Stage,
+		"to_board_id":   p.BoardID,
+		"to_stage":      plan.stage,
+	})
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to record task history").Cause(err).Err()
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to commit transaction").Cause(err).Err()
+	}
+
+	resp, err := loadTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	resp.Warnings = plan.warnings
+	if warning != "" {
+		resp.Warnings = append(resp.Warnings, warning)
+	}
+	return resp, nil
+}
+
+// CopyTaskToBoard creates a copy of a task on another board, created by the authenticated
+// user. It is restricted to users with the task.view permission on the task's board and the
+// task.create permission on the target board. Labels and assignees are carried over as by
+// MoveTaskToBoard; the original task is left unchanged.
+//
+//encore:api auth method=POST path=/task/:taskID/copy-to-board
+func CopyTaskToBoard(ctx context.Context, taskID string, p *TransferTaskParams) (*TaskResponse, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	boardID, _, err := taskOwner(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if p.BoardID == boardID {
+		return nil, errs.B().Code(errs.InvalidArgument).Msg("board_id must be another board than the task's").Err()
+	}
+
+	if _, err := authorize(ctx, boardID, authz.TaskView); err != nil {
+		return nil, err
+	}
+
+	t, err := loadTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := planTransfer(ctx, t, p)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate, err := parseDate("start_date", t.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	dueDate, err := parseDate("due_date", t.DueDate)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := taskDB.Begin(ctx)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to start transaction").Cause(err).Err()
+	}
+	defer tx.Rollback()
+
+	warning, err := checkWIPLimit(ctx, tx, p.BoardID, plan.stage)
+	if err != nil {
+		return nil, err
+	}
+	rank, err := nextRank(ctx, tx, p.BoardID, plan.stage)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to rank task").Cause(err).Err()
+	}
+
+	now := time.Now()
+	var id string
+	err = tx.QueryRow(ctx, `
+        INSERT INTO tasks (board_id, title, description, created_by, stage, stage_id, rank, start_date, due_date, priority, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $11)
+        RETURNING id
+    `, p.BoardID, t.Title, t.Description, uid, plan.stage, plan.stageID, rank, startDate, dueDate, t.Priority, now).Scan(&id)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to copy task").Cause(err).Err()
+	}
+
+	if err := insertAssignees(ctx, tx, id, plan.assigneeIDs, now); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to assign task").Cause(err).Err()
+	}
+	if err := insertLabels(ctx, tx, id, plan.labelIDs); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to attach labels").Cause(err).Err()
+	}
+
+	err = recordHistory(ctx, tx, id, string(uid), actionCopiedFromTask, map[string]string{
+		"source_task_id":  taskID,
+		"source_board_id": t.BoardID,
+	})
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to record task history").Cause(err).Err()
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to commit transaction").Cause(err).Err()
+	}
+
+	resp, err := loadTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	resp.Warnings = plan.warnings
+	if warning != "" {
+		resp.Warnings = append(resp.Warnings, warning)
+	}
+	return resp, nil
+}
+
+// planTransfer checks that the authenticated user may add tasks to the target board and
+// determines the stage, labels and assignees the task gets there.
+func planTransfer(ctx context.Context, t *TaskResponse, p *TransferTaskParams) (*transfer, error) {
+	if p.BoardID == "" {
+		return nil, errs.B().Code(errs.InvalidArgument).Msg("board_id is required").Err()
+	}
+
+	target, err := authorize(ctx, p.BoardID, authz.TaskCreate)
+	if err != nil {
+		return nil, err
+	}
+	if target.Archived {
+		return nil, errs.B().Code(errs.FailedPrecondition).Msg("target board is archived and read-only").Err()
+	}
+	if len(target.Stages) == 0 {
+		return nil, errs.B().Code(errs.FailedPrecondition).Msg("target board has no stages").Err()
+	}
+
+	plan := &transfer{stage: p.Stage}
+	switch {
+	case plan.stage == "" && slices.Contains(target.Stages, t.Stage):
+		plan.stage = t.Stage
+	case plan.stage == "":
+		plan.stage = target.Stages[0]
+	case !slices.Contains(target.Stages, plan.stage):
+		return nil, invalidStageError(target.Stages)
+	}
+	plan.stageID = stageID(target, plan.stage)
+
+	// Labels are board-scoped, so each is replaced by the target board's label of the same name.
+	rows, err := taskDB.Query(ctx, `
+        SELECT l.name, COALESCE(t.id::text, '')
+        FROM task_labels tl
+        JOIN labels l ON l.id = tl.label_id
+        LEFT JOIN labels t ON t.board_id = $2 AND LOWER(t.name) = LOWER(l.name)
+        WHERE tl.task_id = $1
+        ORDER BY l.name
+    `, t.ID, p.BoardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to map labels").Cause(err).Err()
+	}
+	defer rows.Close()
+
+	plan.labelIDs = []string{}
+	var dropped []string
+	for rows.Next() {
+		var name, labelID string
+		if err := rows.Scan(&name, &labelID); err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to scan label").Cause(err).Err()
+		}
+		if labelID == "" {
+			dropped = append(dropped, name)
+			continue
+		}
+		plan.labelIDs = append(plan.labelIDs, labelID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("error reading labels").Cause(err).Err()
+	}
+	if len(dropped) > 0 {
+		plan.warnings = append(plan.warnings, fmt.Sprintf("labels not defined on the target board were removed: '%s'", strings.Join(dropped, "', '")))
+	}
+
+	plan.assigneeIDs = []string{}
+	if len(t.AssigneeIDs) > 0 {
+		members, err := board.CheckMembers(ctx, p.BoardID, &board.CheckMembersParams{UserIDs: t.AssigneeIDs})
+		if err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to check assignee membership").Cause(err).Err()
+		}
+		for _, m := range members.Members {
+			if authz.Has(m.Permissions, authz.TaskUpdateOwn) || authz.Has(m.Permissions, authz.TaskUpdateAny) {
+				plan.assigneeIDs = append(plan.assigneeIDs, m.UserID)
+			}
+		}
+		if removed := len(t.AssigneeIDs) - len(plan.assigneeIDs); removed > 0 {
+			plan.warnings = append(plan.warnings, fmt.Sprintf("%d assignee(s) who cannot be assigned tasks on the target board were unassigned", removed))
+		}
+	}
+
+	return plan, nil
+}
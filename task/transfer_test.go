@@ -0,0 +1,36 @@
+//go:build encore_app
+
+package task
+
+import (
+	"testing"
+
+	"encore.app/board"
+	"encore.dev/beta/errs"
+)
+
+func TestMoveTaskToBoardRequiresVersion(t *testing.T) {
+	boardID, admin := newBoard(t)
+	target, err := board.CreateBoard(as(admin), &board.CreateBoardParams{Name: t.Name() + " target"})
+	if err != nil {
+		t.Fatalf("CreateBoard: %v", err)
+	}
+	task := newTask(t, boardID, admin, &CreateTaskParams{})
+
+	_, err = MoveTaskToBoard(as(admin), task.ID, &TransferTaskParams{BoardID: target.ID})
+	if errs.Code(err) != errs.FailedPrecondition {
+		t.Errorf("move without version: got %v, want FailedPrecondition", err)
+	}
+	_, err = MoveTaskToBoard(as(admin), task.ID, &TransferTaskParams{BoardID: target.ID, Version: task.Version + 1})
+	if errs.Code(err) != errs.Aborted {
+		t.Errorf("move with stale version: got %v, want Aborted", err)
+	}
+
+	moved, err := MoveTaskToBoard(as(admin), task.ID, &TransferTaskParams{BoardID: target.ID, IfMatch: taskETag(task.Version)})
+	if err != nil {
+		t.Fatalf("MoveTaskToBoard: %v", err)
+	}
+	if moved.BoardID != target.ID || moved.Version <= task.Version {
+		t.Errorf("moved task on board %s with version %d, want board %s and a version after %d", moved.BoardID, moved.Version, target.ID, task.Version)
+	}
+}
@@ -0,0 +1,100 @@
+package task
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"encore.dev/beta/errs"
+	"encore.dev/storage/sqldb"
+)
+
+// ConflictDetails is attached to the Aborted error returned when a task was changed by someone
+// else since the client read it.
+type ConflictDetails struct {
+	Current *TaskResponse `json:"current"` // the task as currently stored
+}
+
+// ErrDetails marks ConflictDetails as error details.
+func (ConflictDetails) ErrDetails() {}
+
+// taskETag returns the ETag of a task version.
+func taskETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// expectedVersion returns the task version a client based its change on, taken from the
+// If-Match header or, if that is not set, from the version field.
+func expectedVersion(ifMatch string, version int) (int, error) {
+	if ifMatch == "" {
+		if version <= 0 {
+			return 0, errs.B().Code(errs.FailedPrecondition).Msg("If-Match header or version is required").Err()
+		}
+		return version, nil
+	}
+
+	tag := strings.TrimPrefix(strings.TrimSpace(ifMatch), "W/")
+	v, err := strconv.Atoi(strings.Trim(tag, `"`))
+	if err != nil || v <= 0 {
+		return 0, errs.B().Code(errs.InvalidArgument).Msg("If-Match must be an ETag returned by the server").Err()
+	}
+	return v, nil
+}
+
+// conflictError returns the Aborted error reported when a task no longer has the expected
+// version, carrying the current state of the task.
+func conflictError(ctx context.Context, taskID string) error {
+	current, err := loadTask(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	return errs.B().Code(errs.Aborted).
+		Msgf("task was modified concurrently; current version is %d", current.Version).
+		Details(ConflictDetails{Current: current}).
+		Err()
+}
+
+// loadTask fetches a task with its assignees and labels. It does not check permissions.
+func loadTask(ctx context.Context, taskID string) (*TaskResponse, error) {
+	var t TaskResponse
+	var startDate, dueDate *time.Time
+	var createdAt, updatedAt time.Time
+	err := taskDB.QueryRow(ctx, `
+        SELECT id, board_id, title, COALESCE(description, ''), created_by, stage, rank, start_date, due_date, priority, version, created_at, updated_at
+        FROM tasks
+        WHERE id = $1
+    `, taskID).Scan(&t.ID, &t.BoardID, &t.Title, &t.Description, &t.CreatedBy, &t.Stage, &t.Rank, &startDate, &dueDate, &t.Priority, &t.Version, &createdAt, &updatedAt)
+	if err != nil {
+		if err == sqldb.ErrNoRows {
+			return nil, errs.B().Code(errs.NotFound).Msg("task not found").Err()
+		}
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch task").Cause(err).Err()
+	}
+	t.StartDate = formatDate(startDate)
+	t.DueDate = formatDate(dueDate)
+	t.CreatedAt = createdAt.Format(time.RFC3339)
+	t.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	assignees, err := taskAssignees(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	t.AssigneeIDs = assignees.AssigneeIDs
+	labels, err := taskLabels(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	t.LabelIDs = labels.LabelIDs
+	return &t, nil
+}
+
+// bumpVersion increments the version of a task changed within tx.
+func bumpVersion(ctx context.Context, tx *sqldb.Tx, taskID string) error {
+	_, err := tx.Exec(ctx, `
+        UPDATE tasks
+        SET version = version + 1, updated_at = NOW()
+        WHERE id = $1
+    `, taskID)
+	return err
+}
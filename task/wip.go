@@ -0,0 +1,130 @@
+package task
+
+import (
+	"context"
+	"fmt"
+
+	"encore.app/authz"
+	"encore.app/board"
+	"encore.dev/beta/auth"
+	"encore.dev/beta/errs"
+	"encore.dev/storage/sqldb"
+)
+
+// checkWIPLimit verifies that one more task fits into a stage under its work-in-progress
+// limit. It must run inside the transaction that adds the task to the stage: the stage is
+// locked so concurrent requests cannot exceed the limit together. For stages in soft mode
+// it returns a warning instead of an error.
+func checkWIPLimit(ctx context.Context, tx *sqldb.Tx, boardID, stage string) (string, error) {
+	stages, err := board.ListStageLimits(ctx, boardID)
+	if err != nil {
+		return "", errs.B().Code(errs.Internal).Msg("failed to fetch stages").Cause(err).Err()
+	}
+
+	var limit int
+	var mode string
+	for _, s := range stages.Stages {
+		if s.Name == stage {
+			limit, mode = s.WIPLimit, s.WIPMode
+			break
+		}
+	}
+	if limit == 0 {
+		return "", nil
+	}
+
+	if err := lockStage(ctx, tx, boardID, stage); err != nil {
+		return "", errs.B().Code(errs.Internal).Msg("failed to lock stage").Cause(err).Err()
+	}
+
+	var count int
+	err = tx.QueryRow(ctx, `
+        SELECT COUNT(*) FROM tasks
+        WHERE board_id = $1 AND stage = $2
+    `, boardID, stage).Scan(&count)
+	if err != nil {
+		return "", errs.B().Code(errs.Internal).Msg("failed to count tasks").Cause(err).Err()
+	}
+
+	if count < limit {
+		return "", nil
+	}
+	if mode == "soft" {
+		return fmt.Sprintf("stage '%s' is over its WIP limit (%d/%d)", stage, count+1, limit), nil
+	}
+	return "", errs.B().Code(errs.FailedPrecondition).Msgf("stage '%s' has reached its WIP limit of %d tasks", stage, limit).Err()
+}
+
+// ColumnSummary represents a single stage of a board with its current task count.
+type ColumnSummary struct {
+	Stage     string `json:"stage"`               // stage name
+	Position  int    `json:"position"`            // 0-based column order
+	TaskCount int    `json:"task_count"`          // number of tasks in the stage
+	WIPLimit  int    `json:"wip_limit,omitempty"` // maximum number of tasks, 0 for no limit
+	WIPMode   string `json:"wip_mode"`            // "hard" or "soft"
+	OverLimit bool   `json:"over_limit"`          // true if the task count exceeds the WIP limit
+}
+
+// ColumnSummaryResponse represents the columns of a board with their task counts.
+type ColumnSummaryResponse struct {
+	Columns []ColumnSummary `json:"columns"`
+}
+
+// GetColumnSummary retrieves the stages of a board with their task counts and WIP limits,
+// accessible to users with the task.view permission.
+//
+//encore:api auth method=GET path=/board/:boardID/columns
+func GetColumnSummary(ctx context.Context, boardID string) (*ColumnSummaryResponse, error) {
+	_, ok := auth.UserID()
+	if !ok {
+		return nil, errs.B().Code(errs.Unauthenticated).Msg("authentication required").Err()
+	}
+
+	if _, err := authorize(ctx, boardID, authz.TaskView); err != nil {
+		return nil, err
+	}
+
+	stages, err := board.ListStageLimits(ctx, boardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch stages").Cause(err).Err()
+	}
+
+	rows, err := taskDB.Query(ctx, `
+        SELECT stage, COUNT(*)
+        FROM tasks
+        WHERE board_id = $1
+        GROUP BY stage
+    `, boardID)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to count tasks").Cause(err).Err()
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var stage string
+		var count int
+		if err := rows.Scan(&stage, &count); err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to scan task count").Cause(err).Err()
+		}
+		counts[stage] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("error reading task counts").Cause(err).Err()
+	}
+
+	columns := make([]ColumnSummary, 0, len(stages.Stages))
+	for _, s := range stages.Stages {
+		columns = append(columns, ColumnSummary{
+			Stage:     s.Name,
+			Position:  s.Position,
+			TaskCount: counts[s.Name],
+			WIPLimit:  s.WIPLimit,
+			WIPMode:   s.WIPMode,
+			OverLimit: s.WIPLimit > 0 && counts[s.Name] > s.WIPLimit,
+		})
+	}
+
+	return &ColumnSummaryResponse{Columns: columns}, nil
+}
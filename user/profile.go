@@ -0,0 +1,58 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"encore.dev/beta/errs"
+)
+
+// Profile represents the public details of a user.
+type Profile struct {
+	ID        string `json:"id"`         // user id
+	Email     string `json:"email"`      // user email
+	CreatedAt string `json:"created_at"` // time of signing up
+}
+
+// GetProfilesParams defines the users whose profiles are requested.
+type GetProfilesParams struct {
+	UserIDs []string `json:"user_ids"`
+}
+
+// GetProfilesResponse represents the profiles of the requested users. Unknown users are omitted.
+type GetProfilesResponse struct {
+	Profiles []Profile `json:"profiles"`
+}
+
+// GetProfiles retrieves the profiles of a set of users. It is used by other services to
+// resolve user ids into user details.
+//
+//encore:api private method=POST path=/internal/users/profiles
+func GetProfiles(ctx context.Context, p *GetProfilesParams) (*GetProfilesResponse, error) {
+	rows, err := userDB.Query(ctx, `
+        SELECT id, email, created_at
+        FROM users
+        WHERE id::text = ANY($1::text[])
+    `, p.UserIDs)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("failed to fetch users").Cause(err).Err()
+	}
+	defer rows.Close()
+
+	profiles := []Profile{}
+	for rows.Next() {
+		var pr Profile
+		var createdAt time.Time
+		if err := rows.Scan(&pr.ID, &pr.Email, &createdAt); err != nil {
+			return nil, errs.B().Code(errs.Internal).Msg("failed to scan user").Cause(err).Err()
+		}
+		pr.CreatedAt = createdAt.Format(time.RFC3339)
+		profiles = append(profiles, pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("error reading users").Cause(err).Err()
+	}
+
+	return &GetProfilesResponse{Profiles: profiles}, nil
+}